@@ -0,0 +1,98 @@
+package geometry
+
+import "sync"
+
+// AtomicUint128 is a Uint128 that is safe for concurrent use. 128-bit values
+// can't be updated with a single atomic instruction on every supported platform,
+// so the two limbs are guarded by a mutex.
+//
+// The zero value is ready to use and holds 0. An AtomicUint128 must not be
+// copied after first use.
+type AtomicUint128 struct {
+	mu sync.Mutex
+	v  Uint128
+}
+
+// Load returns the current value.
+func (a *AtomicUint128) Load() Uint128 {
+	a.mu.Lock()
+	v := a.v
+	a.mu.Unlock()
+	return v
+}
+
+// Store sets the value to v.
+func (a *AtomicUint128) Store(v Uint128) {
+	a.mu.Lock()
+	a.v = v
+	a.mu.Unlock()
+}
+
+// Add adds n to the value and returns the new value. Overflow wraps, as with
+// Uint128.Add.
+func (a *AtomicUint128) Add(n Uint128) Uint128 {
+	a.mu.Lock()
+	a.v = a.v.Add(n)
+	v := a.v
+	a.mu.Unlock()
+	return v
+}
+
+// CompareAndSwap sets the value to new if it is currently old, and reports
+// whether the swap happened.
+func (a *AtomicUint128) CompareAndSwap(old, new Uint128) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.v.Equal(old) {
+		return false
+	}
+	a.v = new
+	return true
+}
+
+// AtomicInt128 is an Int128 that is safe for concurrent use. See AtomicUint128
+// for details.
+//
+// The zero value is ready to use and holds 0. An AtomicInt128 must not be
+// copied after first use.
+type AtomicInt128 struct {
+	mu sync.Mutex
+	v  Int128
+}
+
+// Load returns the current value.
+func (a *AtomicInt128) Load() Int128 {
+	a.mu.Lock()
+	v := a.v
+	a.mu.Unlock()
+	return v
+}
+
+// Store sets the value to v.
+func (a *AtomicInt128) Store(v Int128) {
+	a.mu.Lock()
+	a.v = v
+	a.mu.Unlock()
+}
+
+// Add adds n to the value and returns the new value. Overflow wraps, as with
+// Int128.Add.
+func (a *AtomicInt128) Add(n Int128) Int128 {
+	a.mu.Lock()
+	a.v = a.v.Add(n)
+	v := a.v
+	a.mu.Unlock()
+	return v
+}
+
+// CompareAndSwap sets the value to new if it is currently old, and reports
+// whether the swap happened.
+func (a *AtomicInt128) CompareAndSwap(old, new Int128) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.v.Equal(old) {
+		return false
+	}
+	a.v = new
+	return true
+}
@@ -0,0 +1,88 @@
+package geometry
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicUint128Add(t *testing.T) {
+	const workers = 32
+	const adds = 1000
+
+	var a AtomicUint128
+	a.Store(u64(maxUint64 - workers*adds/2)) // crosses into the hi limb part way through
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				a.Add(u64(1))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, u64(maxUint64-workers*adds/2).Add64(workers*adds), a.Load())
+}
+
+func TestAtomicUint128CompareAndSwap(t *testing.T) {
+	const workers = 32
+	const incs = 200
+
+	var a AtomicUint128
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < incs; i++ {
+				for {
+					old := a.Load()
+					if a.CompareAndSwap(old, old.Inc()) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, u64(workers*incs), a.Load())
+	require.False(t, a.CompareAndSwap(u64(0), u64(1)))
+	require.True(t, a.CompareAndSwap(u64(workers*incs), MaxUint128))
+	require.Equal(t, MaxUint128, a.Load())
+}
+
+func TestAtomicInt128Add(t *testing.T) {
+	const workers = 32
+	const adds = 1000
+
+	var a AtomicInt128
+	a.Store(i64(workers * adds / 2))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				a.Add(i64(-1)) // crosses zero part way through
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, i64(-workers*adds/2), a.Load())
+}
+
+func TestAtomicInt128CompareAndSwap(t *testing.T) {
+	var a AtomicInt128
+	a.Store(MinInt128)
+	require.False(t, a.CompareAndSwap(i64(0), i64(1)))
+	require.True(t, a.CompareAndSwap(MinInt128, MaxInt128))
+	require.Equal(t, MaxInt128, a.Load())
+}
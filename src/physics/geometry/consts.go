@@ -69,6 +69,16 @@ var (
 	// Int128 == MinInt128.
 	minInt128AsUint128 = Uint128{hi: 0x8000000000000000, lo: 0x0}
 
+	// pow10Uint128[i] is 10^i. 10^38 is the largest power of ten that fits in
+	// a Uint128.
+	pow10Uint128 = func() (t [39]Uint128) {
+		t[0] = Uint128{lo: 1}
+		for i := 1; i < len(t); i++ {
+			t[i] = t[i-1].Mul64(10)
+		}
+		return t
+	}()
+
 	// This specifies the maximum error allowed between the float64 version of
 	// a 128-bit int/uint and the result of the same operation performed by
 	// big.Float.
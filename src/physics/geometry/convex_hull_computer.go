@@ -1,18 +1,667 @@
 package geometry
 
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
 type ConvexHullComputer struct {
 	Vertices []Vector3
 	Edges    []Edge
 	Faces    []int
 }
 
-func (c *ConvexHullComputer) Compute(coords float64, stride int, count int, shrink Scalar, shrinkClamp Scalar) Scalar {
+// Compute replaces the contents of c with the convex hull of count points
+// read from coords, returning the amount the faces were shrunk by, or -1 if
+// shrinking failed. See compute for the layout of the result and the meaning
+// of shrink and shrinkClamp.
+//
+// The points are packed into coords as X, Y, Z triples, with point i starting
+// at coords[i*stride]; stride is counted in float64s rather than bytes, and
+// is at least 3. Anything between one point's Z and the next point's X is
+// ignored, so coords can hold other per-point data.
+//
+// The hull is found on a grid with 2**19 steps across the points' bounding
+// box along each axis, so points less than about one step apart are merged
+// first. Each vertex of the hull is still one of the input points, but an
+// input point can be left outside the hull by up to a step.
+func (c *ConvexHullComputer) Compute(coords []float64, stride int, count int, shrink Scalar, shrinkClamp Scalar) Scalar {
 	if count <= 0 {
 		c.Vertices = nil
 		c.Edges = nil
 		c.Faces = nil
 		return 0
 	}
+	if stride < 3 {
+		panic(fmt.Errorf("num: convex hull stride %d is less than 3", stride))
+	} else if need := (count-1)*stride + 3; len(coords) < need {
+		panic(fmt.Errorf("num: convex hull of %d points with stride %d needs %d coords, not %d", count, stride, need, len(coords)))
+	}
+
+	points := make([]Vector3, count)
+	for i := range points {
+		p := coords[i*stride:]
+		points[i] = Vector3{X: p[0], Y: p[1], Z: p[2]}
+	}
+	return c.compute(points, shrink, shrinkClamp)
+}
+
+// compute replaces the contents of c with the convex hull of points, returning
+// the amount the faces were shrunk by, or -1 (with c left empty) if shrinking
+// failed.
+//
+// Each entry of c.Faces is the index in c.Edges of one edge of the face; the
+// rest are found with Edge.GetNextEdgeOfFace, which walks the face
+// counter-clockwise as seen from outside the hull. A hull of coplanar points
+// has two faces, one for each side, and a hull of collinear points has a
+// single pair of edges and no faces.
+//
+// If shrink > 0, every face is moved inwards by shrink. If shrinkClamp > 0 as
+// well, the shrinkage is limited to shrinkClamp times the smallest distance
+// from the hull's centroid to a face, as with Bullet's btConvexHullComputer;
+// otherwise shrinking by more than that distance fails. Flat hulls have no
+// inside, so they are never shrunk.
+func (c *ConvexHullComputer) compute(points []Vector3, shrink Scalar, shrinkClamp Scalar) Scalar {
+	c.Vertices, c.Edges, c.Faces = nil, nil, nil
+	if len(points) == 0 {
+		return 0
+	}
+
+	hull := newConvexHull(points)
+	var shift Scalar
+	if shrink > 0 {
+		var ok bool
+		if points, hull, shift, ok = shrinkConvexHull(points, hull, shrink, shrinkClamp); !ok {
+			return -1
+		}
+	}
+	c.setHull(points, hull)
+	return shift
+}
+
+// setHull fills c from hull, whose indices refer to points.
+func (c *ConvexHullComputer) setHull(points []Vector3, hull convexHull) {
+	vertexIndex := make(map[int]int, len(hull.vertices))
+	c.Vertices = make([]Vector3, len(hull.vertices))
+	for i, p := range hull.vertices {
+		vertexIndex[p] = i
+		c.Vertices[i] = points[p]
+	}
+
+	if len(hull.faces) == 0 {
+		if len(hull.vertices) == 2 {
+			// A line segment: a single pair of edges, each the only edge
+			// leaving its source vertex.
+			c.Edges = make([]Edge, 2)
+			c.Edges[0] = Edge{targetVertex: 1, reverse: &c.Edges[1]}
+			c.Edges[1] = Edge{targetVertex: 0, reverse: &c.Edges[0]}
+			c.Edges[0].next, c.Edges[1].next = &c.Edges[0], &c.Edges[1]
+		}
+		return
+	}
+
+	count := 0
+	for _, face := range hull.faces {
+		count += len(face)
+	}
+
+	// Every edge is allocated up front, as the edges point into c.Edges:
+	c.Edges = make([]Edge, 0, count)
+	c.Faces = make([]int, 0, len(hull.faces))
+	edgeIndex := make(map[[2]int]int, count)
+	for _, face := range hull.faces {
+		c.Faces = append(c.Faces, len(c.Edges))
+		for k := range face {
+			from, to := vertexIndex[face[k]], vertexIndex[face[(k+1)%len(face)]]
+			edgeIndex[[2]int{from, to}] = len(c.Edges)
+			c.Edges = append(c.Edges, Edge{targetVertex: to})
+		}
+	}
+
+	for key, e := range edgeIndex {
+		c.Edges[e].reverse = &c.Edges[edgeIndex[[2]int{key[1], key[0]}]]
+	}
+
+	// Edge.GetNextEdgeOfFace is reverse.next, so around each vertex of a face,
+	// the edge back to the previous vertex is followed by the edge on to the
+	// next one:
+	for _, face := range hull.faces {
+		for k := range face {
+			prev := vertexIndex[face[(k+len(face)-1)%len(face)]]
+			v := vertexIndex[face[k]]
+			next := vertexIndex[face[(k+1)%len(face)]]
+			c.Edges[edgeIndex[[2]int{v, prev}]].next = &c.Edges[edgeIndex[[2]int{v, next}]]
+		}
+	}
+}
+
+// hullQuantization is the largest coordinate of the integer grid that points
+// are snapped to before the hull is found. The orientation tests are then
+// exact in an Int64: coordinate differences are below 2**20, so the triple
+// products are below 3 * 2**20 * 2**41.
+const hullQuantization = 1 << 18
+
+// convexHull is the shape of a hull before it's written out: the indices of
+// the input points that are its vertices, in ascending order, and its faces
+// as lists of those indices, counter-clockwise as seen from outside.
+type convexHull struct {
+	vertices []int
+	faces    [][]int
+}
+
+// newConvexHull finds the convex hull of points.
+//
+// The points are snapped to an integer grid of hullQuantization steps either
+// side of the centre of their bounding box along each axis, so that the
+// hull can be found with exact arithmetic. Points that snap to the same place
+// are merged, keeping the one furthest from the centre, which is the one most
+// likely to be on the hull. The vertices of the result are the original
+// points, not the snapped ones.
+func newConvexHull(points []Vector3) convexHull {
+	pts := quantizeHullPoints(points)
 
+	// Find four points that aren't coplanar to start from, or give up on a
+	// solid hull:
+	if len(pts) == 1 {
+		return convexHull{vertices: []int{pts[0].index}}
+	}
+	i2 := 2
+	for ; i2 < len(pts); i2++ {
+		d1, d2 := pts[1].Subtract(pts[0]), pts[i2].Subtract(pts[0])
+		if !d1.Cross32(d2).IsZero() {
+			break
+		}
+	}
+	if i2 == len(pts) {
+		return collinearHull(pts)
+	}
+	i3 := i2 + 1
+	for ; i3 < len(pts); i3++ {
+		if hullOrientation(&pts[0], &pts[1], &pts[i2], &pts[i3]) != 0 {
+			break
+		}
+	}
+	if i3 == len(pts) {
+		return planarHull(pts, i2)
+	}
+	return solidHull(pts, i2, i3)
+}
+
+// quantizeHullPoints snaps points to the grid described by newConvexHull,
+// returning the distinct results with their index set to that of the point
+// furthest from the centre of the grid that snapped there.
+func quantizeHullPoints(points []Vector3) []Point32 {
+	lo, hi := points[0], points[0]
+	for _, p := range points[1:] {
+		lo.X, hi.X = math.Min(lo.X, p.X), math.Max(hi.X, p.X)
+		lo.Y, hi.Y = math.Min(lo.Y, p.Y), math.Max(hi.Y, p.Y)
+		lo.Z, hi.Z = math.Min(lo.Z, p.Z), math.Max(hi.Z, p.Z)
+	}
+	axis := func(lo, hi float64) (center, scale float64) {
+		if hi > lo {
+			scale = 2 * hullQuantization / (hi - lo)
+		}
+		return (lo + hi) / 2, scale
+	}
+	cx, sx := axis(lo.X, hi.X)
+	cy, sy := axis(lo.Y, hi.Y)
+	cz, sz := axis(lo.Z, hi.Z)
+
+	center := Vector3{X: cx, Y: cy, Z: cz}
+	seen := make(map[Point32]int, len(points))
+	pts := make([]Point32, 0, len(points))
+	for i, p := range points {
+		q := Point32{
+			X:     Int32(math.Round((p.X - cx) * sx)),
+			Y:     Int32(math.Round((p.Y - cy) * sy)),
+			Z:     Int32(math.Round((p.Z - cz) * sz)),
+			index: i,
+		}
+		key := q.Canonical()
+		if j, ok := seen[key]; !ok {
+			seen[key] = len(pts)
+			pts = append(pts, q)
+		} else if p.DistanceTo(center) > points[pts[j].index].DistanceTo(center) {
+			pts[j].index = i
+		}
+	}
+	return pts
+}
+
+// hullOrientation returns the sign of ((b-a) x (c-a)) . (d-a), which is
+// positive if d is on the side of the triangle abc that it faces when abc is
+// counter-clockwise.
+func hullOrientation(a, b, c, d *Point32) int {
+	ab, ac, ad := b.Subtract(*a), c.Subtract(*a), d.Subtract(*a)
+	o := ad.Dot64(ab.Cross32(ac))
+	switch {
+	case o > 0:
+		return 1
+	case o < 0:
+		return -1
+	}
 	return 0
 }
+
+func collinearHull(pts []Point32) convexHull {
+	dir := pts[1].Subtract(pts[0])
+	dir64 := Point64{X: Int64(dir.X), Y: Int64(dir.Y), Z: Int64(dir.Z)}
+	lo, hi := 0, 0
+	var loT, hiT Int64
+	for i := range pts {
+		d := pts[i].Subtract(pts[0])
+		t := d.Dot64(dir64)
+		if t < loT {
+			lo, loT = i, t
+		} else if t > hiT {
+			hi, hiT = i, t
+		}
+	}
+	vertices := []int{pts[lo].index, pts[hi].index}
+	sort.Ints(vertices)
+	return convexHull{vertices: vertices}
+}
+
+// planarHull finds the hull of pts, which are all in the plane of pts[0],
+// pts[1] and pts[i2], using a gift wrap. The result has two faces, one
+// facing each way.
+func planarHull(pts []Point32, i2 int) convexHull {
+	d1, d2 := pts[1].Subtract(pts[0]), pts[i2].Subtract(pts[0])
+	normal := d1.Cross32(d2)
+
+	// Orientation within the plane is the sign of the cross product along the
+	// normal, which can be read from any of its non-zero components:
+	axis, axisSign := 0, 0
+	for i, n := range []Int64{normal.X, normal.Y, normal.Z} {
+		if n != 0 {
+			axis, axisSign = i, 1
+			if n < 0 {
+				axisSign = -1
+			}
+			break
+		}
+	}
+	orientation := func(a, b, c *Point32) int {
+		ab, ac := b.Subtract(*a), c.Subtract(*a)
+		cross := ab.Cross32(ac)
+		o := [3]Int64{cross.X, cross.Y, cross.Z}[axis]
+		switch {
+		case o > 0:
+			return axisSign
+		case o < 0:
+			return -axisSign
+		}
+		return 0
+	}
+
+	// The lexicographically smallest point is always on the hull:
+	start := 0
+	for i := range pts {
+		p, s := pts[i], pts[start]
+		if p.X < s.X || (p.X == s.X && (p.Y < s.Y || (p.Y == s.Y && p.Z < s.Z))) {
+			start = i
+		}
+	}
+
+	var front []int
+	for cur := start; ; {
+		front = append(front, pts[cur].index)
+		next := -1
+		for i := range pts {
+			if i == cur {
+				continue
+			} else if next < 0 {
+				next = i
+				continue
+			}
+			// Keep every point to the left of cur->next, and on ties take
+			// the farthest so collinear points are skipped:
+			switch orientation(&pts[cur], &pts[next], &pts[i]) {
+			case -1:
+				next = i
+			case 0:
+				di, dn := pts[i].Subtract(pts[cur]), pts[next].Subtract(pts[cur])
+				if di.LengthSquared() > dn.LengthSquared() {
+					next = i
+				}
+			}
+		}
+		if cur = next; cur == start {
+			break
+		}
+	}
+
+	back := make([]int, len(front))
+	for i, p := range front {
+		back[len(front)-1-i] = p
+	}
+	vertices := append([]int(nil), front...)
+	sort.Ints(vertices)
+	return convexHull{vertices: vertices, faces: [][]int{front, back}}
+}
+
+// hullTriangle is a triangle of the hull being built by solidHull. face holds
+// its plane, v its corners as indices into the points, counter-clockwise from
+// outside, and outside the points not yet added that can see it.
+type hullTriangle struct {
+	face    Face
+	v       [3]int
+	outside []int
+	dead    bool
+}
+
+// solidHull finds the hull of pts by adding the points one at a time to the
+// tetrahedron pts[0], pts[1], pts[i2], pts[i3], replacing the triangles each
+// point can see with a fan of triangles joining it to their boundary. The
+// triangles are then merged into faces where they are coplanar.
+//
+// Each triangle keeps a conflict list of the points outside it, and each point
+// the triangles it's outside, so adding a point only looks at the triangles it
+// can see. A point outside a new triangle must have been outside one of the
+// two triangles either side of the horizon edge it was built on, so only
+// their conflict lists need checking.
+func solidHull(pts []Point32, i2, i3 int) convexHull {
+	verts := make([]Vertex, len(pts))
+	for i := range pts {
+		verts[i].Point = pts[i]
+	}
+
+	var tris []*hullTriangle
+	owner := make(map[[2]int]int)        // The triangle with each directed edge
+	conflicts := make([][]int, len(pts)) // The triangles each point is outside
+	added := make([]bool, len(pts))
+
+	addTriangle := func(a, b, c int) int {
+		t := len(tris)
+		tri := &hullTriangle{v: [3]int{a, b, c}}
+		tri.face.init(&verts[a], &verts[b], &verts[c])
+		tris = append(tris, tri)
+		owner[[2]int{a, b}], owner[[2]int{b, c}], owner[[2]int{c, a}] = t, t, t
+		return t
+	}
+	addConflict := func(t, p int) {
+		if tris[t].face.side(&pts[p]) > 0 {
+			tris[t].outside = append(tris[t].outside, p)
+			conflicts[p] = append(conflicts[p], t)
+		}
+	}
+
+	i0, i1 := 0, 1
+	if hullOrientation(&pts[i0], &pts[i1], &pts[i2], &pts[i3]) > 0 {
+		i1, i2 = i2, i1
+	}
+	addTriangle(i0, i1, i2)
+	addTriangle(i0, i3, i1)
+	addTriangle(i1, i3, i2)
+	addTriangle(i2, i3, i0)
+	for _, p := range []int{i0, i1, i2, i3} {
+		added[p] = true
+	}
+	for p := range pts {
+		for t := 0; t < len(tris) && !added[p]; t++ {
+			addConflict(t, p)
+		}
+	}
+
+	// checked[q] is one more than the last new triangle q was tested against,
+	// as q can be on the conflict lists of both triangles beside an edge:
+	checked := make([]int, len(pts))
+	for p := range pts {
+		if added[p] {
+			continue
+		}
+		added[p] = true
+
+		// Some of p's triangles may have died since they were listed, but
+		// any that replaced them and that p is outside were listed as well:
+		visible := map[int]bool{}
+		for _, t := range conflicts[p] {
+			if !tris[t].dead {
+				visible[t] = true
+			}
+		}
+		conflicts[p] = nil
+		if len(visible) == 0 {
+			continue // Inside the hull, or on its surface
+		}
+
+		// Each edge of the horizon, with the visible triangle inside it and
+		// the hidden one outside:
+		type horizonEdge struct{ a, b, in, out int }
+		var horizon []horizonEdge
+		for t := range visible {
+			v := tris[t].v
+			for k := 0; k < 3; k++ {
+				a, b := v[k], v[(k+1)%3]
+				if u := owner[[2]int{b, a}]; !visible[u] {
+					horizon = append(horizon, horizonEdge{a, b, t, u})
+				}
+			}
+		}
+		for t := range visible {
+			tris[t].dead = true
+			v := tris[t].v
+			for k := 0; k < 3; k++ {
+				delete(owner, [2]int{v[k], v[(k+1)%3]})
+			}
+		}
+		for _, e := range horizon {
+			t := addTriangle(e.a, e.b, p)
+			for _, list := range [][]int{tris[e.in].outside, tris[e.out].outside} {
+				for _, q := range list {
+					if !added[q] && checked[q] != t+1 {
+						checked[q] = t + 1
+						addConflict(t, q)
+					}
+				}
+			}
+		}
+		for t := range visible {
+			tris[t].outside = nil
+		}
+	}
+
+	var live []int
+	for t := range tris {
+		if !tris[t].dead {
+			live = append(live, t)
+		}
+	}
+
+	// Merge coplanar neighbours. On a convex hull they always face the same
+	// way, so the merged faces are convex polygons:
+	group := make([]int, len(tris))
+	for t := range group {
+		group[t] = t
+	}
+	var find func(t int) int
+	find = func(t int) int {
+		if group[t] != t {
+			group[t] = find(group[t])
+		}
+		return group[t]
+	}
+	for _, t := range live {
+		v := tris[t].v
+		for k := 0; k < 3; k++ {
+			u := owner[[2]int{v[(k+1)%3], v[k]}]
+			w := tris[u].v
+			apex := w[0] + w[1] + w[2] - v[k] - v[(k+1)%3]
+			if u > t && tris[t].face.side(&pts[apex]) == 0 {
+				group[find(u)] = find(t)
+			}
+		}
+	}
+
+	// The boundary of each group is made of the edges whose reverse belongs
+	// to a different group:
+	boundary := map[int]map[int]int{}
+	var order []int
+	for _, t := range live {
+		g, v := find(t), tris[t].v
+		for k := 0; k < 3; k++ {
+			a, b := v[k], v[(k+1)%3]
+			if find(owner[[2]int{b, a}]) == g {
+				continue
+			}
+			if boundary[g] == nil {
+				boundary[g] = map[int]int{}
+				order = append(order, g)
+			}
+			boundary[g][a] = b
+		}
+	}
+
+	used := map[int]bool{}
+	var faces [][]int
+	for _, g := range order {
+		start := -1
+		for v := range boundary[g] {
+			if start < 0 || v < start {
+				start = v
+			}
+		}
+		face := []int{start}
+		for v := boundary[g][start]; v != start; v = boundary[g][v] {
+			face = append(face, v)
+		}
+		face = removeCollinearHullVertices(pts, face)
+		for i, v := range face {
+			used[v] = true
+			face[i] = pts[v].index
+		}
+		faces = append(faces, face)
+	}
+
+	vertices := make([]int, 0, len(used))
+	for v := range used {
+		vertices = append(vertices, pts[v].index)
+	}
+	sort.Ints(vertices)
+	return convexHull{vertices: vertices, faces: faces}
+}
+
+// removeCollinearHullVertices drops the vertices of face that lie on a
+// straight line between their neighbours. These are points that were on the
+// hull when they were added, but were later covered by an edge.
+func removeCollinearHullVertices(pts []Point32, face []int) []int {
+	for removed := true; removed && len(face) > 3; {
+		removed = false
+		for k := 0; k < len(face); k++ {
+			prev, v, next := face[(k+len(face)-1)%len(face)], face[k], face[(k+1)%len(face)]
+			d1, d2 := pts[v].Subtract(pts[prev]), pts[next].Subtract(pts[v])
+			if d1.Cross32(d2).IsZero() {
+				face = append(face[:k], face[k+1:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	return face
+}
+
+// shrinkConvexHull moves each face of hull inwards by up to amount, returning
+// the new points and hull. shift is the amount actually used, which is
+// limited to clamp times the distance from the centroid to the nearest face
+// if clamp > 0.
+// Flat hulls are returned unchanged with shift 0, and ok is false if the
+// shrunk hull is degenerate.
+//
+// The shrunk hull is the intersection of the shifted faces' half-spaces, which
+// is found as the dual of the hull of the points n/d, where n is the unit
+// normal of a face and d its shifted distance from the centroid.
+func shrinkConvexHull(points []Vector3, hull convexHull, amount, clamp Scalar) (shrunk []Vector3, out convexHull, shift Scalar, ok bool) {
+	if len(hull.faces) < 4 {
+		return points, hull, 0, true
+	}
+
+	// The centroid of the hull, from a fan of tetrahedra about one vertex:
+	ref := points[hull.vertices[0]]
+	var center Vector3
+	var volume Scalar
+	for _, face := range hull.faces {
+		a := points[face[0]].Subtract(&ref)
+		for k := 1; k+1 < len(face); k++ {
+			b, c := points[face[k]].Subtract(&ref), points[face[k+1]].Subtract(&ref)
+			bc := b.Cross(&c)
+			v := a.Dot(&bc)
+			sum := a.Add(&b)
+			sum = sum.Add(&c)
+			center = center.Add(&Vector3{X: sum.X * float64(v), Y: sum.Y * float64(v), Z: sum.Z * float64(v)})
+			volume += v
+		}
+	}
+	if volume <= 0 {
+		return points, hull, 0, true
+	}
+	center = center.Scale(1 / (4 * volume))
+	center = center.Add(&ref)
+
+	normals := make([]Vector3, len(hull.faces))
+	dists := make([]Scalar, len(hull.faces))
+	minDist := Scalar(Infinity)
+	for i, face := range hull.faces {
+		n, ok := hullFaceNormal(points, face).Normalize()
+		if !ok {
+			return nil, out, 0, false
+		}
+		toFace := points[face[0]].Subtract(&center)
+		normals[i], dists[i] = n, n.Dot(&toFace)
+		if dists[i] < minDist {
+			minDist = dists[i]
+		}
+	}
+	if minDist <= 0 {
+		return points, hull, 0, true
+	}
+	if clamp > 0 {
+		if limit := minDist * clamp; amount > limit {
+			amount = limit
+		}
+	}
+	if amount <= 0 {
+		return points, hull, 0, true
+	}
+
+	dual := make([]Vector3, len(normals))
+	for i, n := range normals {
+		d := dists[i] - amount
+		if d <= 0 {
+			return nil, out, 0, false
+		}
+		dual[i] = n.Scale(1 / d)
+	}
+	dualHull := newConvexHull(dual)
+	if len(dualHull.faces) < 4 {
+		return nil, out, 0, false
+	}
+
+	// Each face of the dual hull, on the plane m.y == 1, is the vertex m of
+	// the shrunk hull:
+	shrunk = make([]Vector3, 0, len(dualHull.faces))
+	for _, face := range dualHull.faces {
+		n := hullFaceNormal(dual, face)
+		m := n.Scale(1 / n.Dot(&dual[face[0]]))
+		shrunk = append(shrunk, m.Add(&center))
+	}
+	out = newConvexHull(shrunk)
+	if len(out.faces) < 4 {
+		return nil, out, 0, false
+	}
+	return shrunk, out, amount, true
+}
+
+// hullFaceNormal returns the normal of a face using Newell's method, which
+// uses every vertex so that it copes with faces that aren't quite flat. It
+// points out of the hull, and its length is twice the area of the face.
+func hullFaceNormal(points []Vector3, face []int) Vector3 {
+	var n Vector3
+	for k := range face {
+		a, b := points[face[k]], points[face[(k+1)%len(face)]]
+		n.X += (a.Y - b.Y) * (a.Z + b.Z)
+		n.Y += (a.Z - b.Z) * (a.X + b.X)
+		n.Z += (a.X - b.X) * (a.Y + b.Y)
+	}
+	return n
+}
@@ -0,0 +1,302 @@
+package geometry
+
+import (
+	"math"
+	mathrand "math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// hullFaces returns the vertex indices of each face of c, walking the edges
+// with GetNextEdgeOfFace.
+func hullFaces(t *testing.T, c *ConvexHullComputer) [][]int {
+	var faces [][]int
+	for _, f := range c.Faces {
+		var face []int
+		first := &c.Edges[f]
+		for e := first; ; {
+			face = append(face, e.GetTargetVertex())
+			require.Less(t, len(face), len(c.Edges), "face doesn't close")
+			if e = e.GetNextEdgeOfFace(); e == first {
+				break
+			}
+		}
+		faces = append(faces, face)
+	}
+	return faces
+}
+
+// checkHull checks the edge structure of c, that every face is flat, convex
+// and faces outwards, and that every point is inside the hull.
+func checkHull(t *testing.T, c *ConvexHullComputer, points []Vector3, eps Scalar) {
+	for i := range c.Edges {
+		e := &c.Edges[i]
+		require.Same(t, e, e.reverse.reverse)
+		require.NotEqual(t, e.GetTargetVertex(), e.reverse.GetTargetVertex())
+		require.Equal(t, e.reverse.GetTargetVertex(), e.GetNextEdgeOfVertex().reverse.GetTargetVertex(), "next edge of a vertex has a different source")
+	}
+
+	faces := hullFaces(t, c)
+	edges := 0
+	for _, face := range faces {
+		edges += len(face)
+	}
+	if len(faces) > 0 {
+		require.Equal(t, len(c.Edges), edges, "every edge is on exactly one face")
+	}
+	if len(faces) > 2 {
+		require.Equal(t, 2, len(c.Vertices)-len(c.Edges)/2+len(faces), "Euler characteristic")
+	}
+
+	for _, face := range faces {
+		normal, ok := hullFaceNormal(c.Vertices, face).Normalize()
+		require.True(t, ok)
+		origin := c.Vertices[face[0]]
+		for k, v := range face {
+			// Flat:
+			d := c.Vertices[v].Subtract(&origin)
+			require.InDelta(t, 0, float64(normal.Dot(&d)), float64(eps))
+
+			// Convex, turning left about the normal at every vertex:
+			a, b := c.Vertices[face[(k+len(face)-1)%len(face)]], c.Vertices[face[(k+1)%len(face)]]
+			in, out := c.Vertices[v].Subtract(&a), b.Subtract(&c.Vertices[v])
+			turn := in.Cross(&out)
+			require.Greater(t, float64(turn.Dot(&normal)), -float64(eps))
+		}
+		if len(faces) <= 2 {
+			continue
+		}
+		for _, p := range points {
+			d := p.Subtract(&origin)
+			require.LessOrEqual(t, float64(normal.Dot(&d)), float64(eps), "%+v is outside the hull", p)
+		}
+	}
+}
+
+func sortedHullVertices(c *ConvexHullComputer) []Vector3 {
+	vertices := append([]Vector3(nil), c.Vertices...)
+	sort.Slice(vertices, func(i, j int) bool {
+		a, b := vertices[i], vertices[j]
+		if a.X != b.X {
+			return a.X < b.X
+		} else if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.Z < b.Z
+	})
+	return vertices
+}
+
+func cubePoints(half float64) []Vector3 {
+	var points []Vector3
+	for _, x := range []float64{-half, half} {
+		for _, y := range []float64{-half, half} {
+			for _, z := range []float64{-half, half} {
+				points = append(points, Vector3{X: x, Y: y, Z: z})
+			}
+		}
+	}
+	return points
+}
+
+func TestConvexHullComputerTetrahedron(t *testing.T) {
+	points := []Vector3{
+		{X: 0, Y: 0, Z: 0},
+		{X: 1, Y: 0, Z: 0},
+		{X: 0, Y: 1, Z: 0},
+		{X: 0, Y: 0, Z: 1},
+		{X: 0.1, Y: 0.1, Z: 0.1}, // Inside
+		{X: 0.5, Y: 0.5, Z: 0},   // On a face's edge
+	}
+	var c ConvexHullComputer
+	require.Equal(t, Scalar(0), c.compute(points, 0, 0))
+	require.Equal(t, points[:4], c.Vertices)
+	require.Len(t, c.Faces, 4)
+	require.Len(t, c.Edges, 12)
+	for _, face := range hullFaces(t, &c) {
+		require.Len(t, face, 3)
+	}
+	checkHull(t, &c, points, 1e-12)
+}
+
+func TestConvexHullComputerCube(t *testing.T) {
+	points := cubePoints(1)
+
+	// Points on the faces, edges and inside shouldn't add anything:
+	points = append(points,
+		Vector3{}, Vector3{X: 1}, Vector3{Y: -1}, Vector3{X: 1, Y: 1}, Vector3{X: 0.5, Y: -1, Z: 1},
+		Vector3{X: 0.25, Y: 0.5, Z: -0.75}, Vector3{X: -1, Y: 0.3, Z: 0.2})
+
+	// Adding the corners last means earlier points end up on edges and in
+	// the middle of faces:
+	rng := mathrand.New(mathrand.NewSource(1))
+	rng.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+
+	var c ConvexHullComputer
+	require.Equal(t, Scalar(0), c.compute(points, 0, 0))
+	require.Equal(t, cubePoints(1), sortedHullVertices(&c))
+	require.Len(t, c.Faces, 6)
+	require.Len(t, c.Edges, 24)
+	for _, face := range hullFaces(t, &c) {
+		require.Len(t, face, 4)
+	}
+	checkHull(t, &c, points, 1e-12)
+}
+
+func TestConvexHullComputerRandom(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		points := make([]Vector3, 4+rng.Intn(200))
+		for j := range points {
+			points[j] = Vector3{X: rng.NormFloat64(), Y: rng.NormFloat64() * 10, Z: rng.NormFloat64() / 10}
+		}
+		var c ConvexHullComputer
+		c.compute(points, 0, 0)
+		require.GreaterOrEqual(t, len(c.Faces), 4)
+		checkHull(t, &c, points, 1e-4)
+	}
+}
+
+func TestConvexHullComputerSphere(t *testing.T) {
+	// Every point is on the hull, so each one replaces some triangles:
+	rng := mathrand.New(mathrand.NewSource(1))
+	points := make([]Vector3, 500)
+	for i := range points {
+		z, a := rng.Float64()*2-1, rng.Float64()*2*math.Pi
+		r := math.Sqrt(1 - z*z)
+		points[i] = Vector3{X: r * math.Cos(a), Y: r * math.Sin(a), Z: z}
+	}
+	var c ConvexHullComputer
+	c.compute(points, 0, 0)
+	require.Len(t, c.Vertices, len(points))
+
+	// The hull is found on the grid, so faces merged there aren't quite flat
+	// in floating point, as with TestConvexHullComputerRandom:
+	checkHull(t, &c, points, 1e-4)
+}
+
+func TestConvexHullComputerFlat(t *testing.T) {
+	var c ConvexHullComputer
+
+	// A square, with a point in the middle and one on an edge:
+	square := []Vector3{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 0.5, Y: 0.5, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 0, Y: 1, Z: 1}, {X: 0.5, Y: 0, Z: 1}}
+	require.Equal(t, Scalar(0), c.compute(square, 1, 1))
+	require.Equal(t, []Vector3{square[0], square[1], square[3], square[4]}, c.Vertices)
+	require.Len(t, c.Faces, 2)
+	require.Len(t, c.Edges, 8)
+	checkHull(t, &c, square, 1e-12)
+	faces := hullFaces(t, &c)
+	front := hullFaceNormal(c.Vertices, faces[0])
+	back := hullFaceNormal(c.Vertices, faces[1])
+	require.Equal(t, front.Negate(), back)
+
+	// A line, which has a single pair of edges and no faces:
+	line := []Vector3{{X: 1, Y: 1, Z: 1}, {X: 3, Y: 3, Z: 3}, {X: 2, Y: 2, Z: 2}, {X: 0, Y: 0, Z: 0}}
+	c.compute(line, 0, 0)
+	require.Equal(t, []Vector3{line[1], line[3]}, c.Vertices)
+	require.Empty(t, c.Faces)
+	require.Len(t, c.Edges, 2)
+	checkHull(t, &c, line, 0)
+
+	// A single point, repeated:
+	c.compute([]Vector3{{X: 1}, {X: 1}}, 0, 0)
+	require.Equal(t, []Vector3{{X: 1}}, c.Vertices)
+	require.Empty(t, c.Edges)
+	require.Empty(t, c.Faces)
+
+	c.compute(nil, 0, 0)
+	require.Empty(t, c.Vertices)
+}
+
+func TestConvexHullComputerMergedPoints(t *testing.T) {
+	// A cube 2 wide has a grid step of 2**-18, so the extra point snaps to the
+	// same place as the corner it's just outside. The hull should keep the
+	// extra point either way round:
+	outer := Vector3{X: 1 + 1e-7, Y: 1 + 1e-7, Z: 1 + 1e-7}
+	for _, points := range [][]Vector3{
+		append(cubePoints(1), outer),
+		append([]Vector3{outer}, cubePoints(1)...),
+	} {
+		var c ConvexHullComputer
+		c.compute(points, 0, 0)
+		require.Len(t, c.Vertices, 8)
+		require.Contains(t, c.Vertices, outer)
+		require.NotContains(t, c.Vertices, Vector3{X: 1, Y: 1, Z: 1})
+		require.Len(t, c.Faces, 6)
+	}
+}
+
+func TestConvexHullComputerShrink(t *testing.T) {
+	var c ConvexHullComputer
+
+	require.InDelta(t, 0.1, float64(c.compute(cubePoints(1), 0.1, 1)), 1e-12)
+	require.Len(t, c.Faces, 6)
+	for i, v := range sortedHullVertices(&c) {
+		require.True(t, v.ApproxEquals(cubePoints(0.9)[i], 1e-9), "%+v", v)
+	}
+	checkHull(t, &c, cubePoints(0.9), 1e-9)
+
+	// The shrinkage is limited to a fraction of the distance to the nearest
+	// face, which is 1:
+	require.InDelta(t, 0.25, float64(c.compute(cubePoints(1), 5, 0.25)), 1e-12)
+	for i, v := range sortedHullVertices(&c) {
+		require.True(t, v.ApproxEquals(cubePoints(0.75)[i], 1e-9), "%+v", v)
+	}
+
+	// A clamp of 0 doesn't limit the shrinkage at all, so shrinking past the
+	// centroid fails:
+	require.InDelta(t, 0.5, float64(c.compute(cubePoints(1), 0.5, 0)), 1e-12)
+	for i, v := range sortedHullVertices(&c) {
+		require.True(t, v.ApproxEquals(cubePoints(0.5)[i], 1e-9), "%+v", v)
+	}
+	require.Equal(t, Scalar(-1), c.compute(cubePoints(1), 1, 0))
+	require.Empty(t, c.Vertices)
+
+	// The centroid isn't the centre of the bounding box here:
+	points := []Vector3{{X: 0, Y: 0, Z: 0}, {X: 3, Y: 0, Z: 0}, {X: 0, Y: 3, Z: 0}, {X: 0, Y: 0, Z: 3}}
+	require.InDelta(t, 0.1, float64(c.compute(points, 0.1, 1)), 1e-12)
+	require.Len(t, c.Faces, 4)
+	checkHull(t, &c, nil, 1e-9)
+	for _, v := range c.Vertices {
+		// The three faces on the axis planes have moved to 0.1:
+		require.InDelta(t, 0.1, math.Min(v.X, math.Min(v.Y, v.Z)), 1e-9, "%+v", v)
+	}
+
+	// Flat hulls aren't shrunk:
+	square := []Vector3{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+	require.Equal(t, Scalar(0), c.compute(square, 0.1, 1))
+	require.Len(t, c.Vertices, 4)
+}
+
+func TestConvexHullComputerCompute(t *testing.T) {
+	// A tetrahedron, packed with a fourth value per point that isn't a
+	// coordinate:
+	coords := []float64{
+		0, 0, 0, -1,
+		1, 0, 0, -1,
+		0, 1, 0, -1,
+		0.1, 0.1, 0.1, -1,
+		0, 0, 1,
+	}
+	var c ConvexHullComputer
+	require.Equal(t, Scalar(0), c.Compute(coords, 4, 5, 0, 0))
+	require.Equal(t, []Vector3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}, c.Vertices)
+	require.Len(t, c.Faces, 4)
+	require.Len(t, c.Edges, 12)
+
+	// Tightly packed, counting only the first three points:
+	require.Equal(t, Scalar(0), c.Compute([]float64{0, 0, 0, 1, 0, 0, 0, 1, 0, 9, 9, 9}, 3, 3, 0, 0))
+	require.Len(t, c.Vertices, 3)
+	require.Len(t, c.Faces, 2)
+
+	require.Equal(t, Scalar(0), c.Compute(nil, 3, 0, 0, 0))
+	require.Empty(t, c.Vertices)
+	require.Empty(t, c.Edges)
+	require.Empty(t, c.Faces)
+
+	require.Panics(t, func() { c.Compute(coords, 2, 2, 0, 0) })
+	require.Panics(t, func() { c.Compute(coords, 4, 6, 0, 0) })
+	require.Panics(t, func() { c.Compute(coords[:18], 4, 5, 0, 0) })
+}
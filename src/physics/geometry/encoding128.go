@@ -0,0 +1,186 @@
+package geometry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Encoding selects the wire format read by DecodeUint128 and DecodeInt128.
+type Encoding int
+
+const (
+	// EncodingBigEndian is 16 bytes, most significant first, as written by
+	// PutBigEndian. Int128 values are in two's complement.
+	EncodingBigEndian Encoding = iota
+
+	// EncodingLittleEndian is 16 bytes, least significant first, as written by
+	// PutLittleEndian. Int128 values are in two's complement.
+	EncodingLittleEndian
+
+	// EncodingUvarint is the encoding/binary varint format extended to 128
+	// bits: 7 bits per byte, least significant group first, with the high bit
+	// set on every byte but the last. Int128 values are zig-zag encoded first,
+	// as with binary.PutVarint. At most MaxVarintLen128 bytes are read.
+	EncodingUvarint
+)
+
+// MaxVarintLen128 is the maximum length of a 128-bit EncodingUvarint value.
+const MaxVarintLen128 = 19
+
+// DecodeUint128 reads a single Uint128 in encoding enc from r, consuming exactly
+// the bytes that make up the value and nothing more.
+//
+// If r is empty, the error is io.EOF. If r ends part way through the value, the
+// error wraps io.ErrUnexpectedEOF. EncodingUvarint input is rejected if it
+// doesn't fit in 128 bits, or if it is overlong, i.e. ends with a redundant
+// zero group that a canonical encoder would never write.
+func DecodeUint128(r io.Reader, enc Encoding) (Uint128, error) {
+	switch enc {
+	case EncodingBigEndian, EncodingLittleEndian:
+		var b [16]byte
+		if n, err := io.ReadFull(r, b[:]); err != nil {
+			if n == 0 && err == io.EOF {
+				return Uint128{}, io.EOF
+			}
+			return Uint128{}, fmt.Errorf("num: u128 truncated after %d of 16 bytes: %w", n, io.ErrUnexpectedEOF)
+		}
+		if enc == EncodingBigEndian {
+			return MustUint128FromBigEndian(b[:]), nil
+		}
+		return MustUint128FromLittleEndian(b[:]), nil
+
+	case EncodingUvarint:
+		return decodeUvarint128(r)
+
+	default:
+		return Uint128{}, fmt.Errorf("num: unknown encoding %d", enc)
+	}
+}
+
+// DecodeInt128 is the Int128 counterpart of DecodeUint128. The fixed-width
+// encodings are read as two's complement; EncodingUvarint is read as a
+// zig-zag encoded value, so small negative numbers stay short.
+func DecodeInt128(r io.Reader, enc Encoding) (Int128, error) {
+	u, err := DecodeUint128(r, enc)
+	if err != nil {
+		return Int128{}, err
+	}
+	if enc == EncodingUvarint {
+		// Undo the zig-zag: (u >> 1) ^ -(u & 1)
+		v := u.Rsh(1)
+		if u.lo&1 != 0 {
+			v = v.Not()
+		}
+		return v.AsInt128(), nil
+	}
+	return u.AsInt128(), nil
+}
+
+func decodeUvarint128(r io.Reader) (u Uint128, err error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &singleByteReader{r: r}
+	}
+
+	var shift uint
+	for i := 0; i < MaxVarintLen128; i++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			if i == 0 && err == io.EOF {
+				return Uint128{}, io.EOF
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return Uint128{}, fmt.Errorf("num: u128 varint truncated after %d bytes: %w", i, err)
+		}
+
+		if i == MaxVarintLen128-1 && b > 3 {
+			// Only bits 126 and 127 remain, and no further bytes are allowed:
+			return Uint128{}, fmt.Errorf("num: u128 varint overflows 128 bits")
+		}
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return Uint128{}, fmt.Errorf("num: u128 varint overlong encoding")
+			}
+			return u.Or(Uint128From64(Uint64(b)).Lsh(shift)), nil
+		}
+		u = u.Or(Uint128From64(Uint64(b & 0x7f)).Lsh(shift))
+		shift += 7
+	}
+
+	// Unreachable: the last byte is either rejected above or ends the loop.
+	return Uint128{}, fmt.Errorf("num: u128 varint overflows 128 bits")
+}
+
+// singleByteReader adapts an io.Reader to io.ByteReader without buffering, so
+// that decoding a varint never consumes bytes past the end of the value.
+type singleByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (s *singleByteReader) ReadByte() (byte, error) {
+	n, err := s.r.Read(s.buf[:])
+	for n == 0 && err == nil {
+		n, err = s.r.Read(s.buf[:])
+	}
+	if n == 1 {
+		return s.buf[0], nil
+	}
+	return 0, err
+}
+
+// ParseUint128Lines reads newline-delimited decimal Uint128 values from r and
+// calls fn with each one in order. Surrounding whitespace is trimmed, so
+// "\r\n" line endings are fine, and blank lines are skipped.
+//
+// Parsing stops at the first malformed or out of range line, or the first error
+// returned by fn. Either is returned wrapped with the 1-based line number; use
+// errors.Is or errors.As to get at an error from fn.
+//
+// Each line is parsed with a decimal-only parser that doesn't go through
+// big.Int, so this is much cheaper than fmt.Fscan for bulk input. Lines are
+// limited to bufio.MaxScanTokenSize bytes.
+func ParseUint128Lines(r io.Reader, fn func(Uint128) error) error {
+	return scanLines(r, func(line string) error {
+		u, err := parseUint128Decimal(line)
+		if err != nil {
+			return err
+		}
+		return fn(u)
+	})
+}
+
+// ParseInt128Lines is the Int128 counterpart of ParseUint128Lines. Each value
+// may have a leading '-' or '+'.
+func ParseInt128Lines(r io.Reader, fn func(Int128) error) error {
+	return scanLines(r, func(line string) error {
+		i, err := parseInt128Decimal(line)
+		if err != nil {
+			return err
+		}
+		return fn(i)
+	})
+}
+
+func scanLines(r io.Reader, fn func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return fmt.Errorf("num: line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("num: line %d: %w", n+1, err)
+	}
+	return nil
+}
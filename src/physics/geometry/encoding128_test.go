@@ -0,0 +1,284 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readerOnly hides any io.ByteReader implementation of the wrapped reader.
+type readerOnly struct{ r io.Reader }
+
+func (r readerOnly) Read(p []byte) (int, error) { return r.r.Read(p) }
+
+func appendUvarint128(b []byte, u Uint128) []byte {
+	for u.GreaterOrEqualTo(u64(0x80)) {
+		b = append(b, byte(u.lo)|0x80)
+		u = u.Rsh(7)
+	}
+	return append(b, byte(u.lo))
+}
+
+func TestDecodeUint128(t *testing.T) {
+	bts := make([]byte, 16)
+	trailer := []byte{0xde, 0xad}
+
+	check := func(u Uint128) {
+		var be, le [16]byte
+		u.PutBigEndian(be[:])
+		u.PutLittleEndian(le[:])
+		for _, tc := range []struct {
+			enc Encoding
+			in  []byte
+		}{
+			{EncodingBigEndian, be[:]},
+			{EncodingLittleEndian, le[:]},
+			{EncodingUvarint, appendUvarint128(nil, u)},
+		} {
+			for _, wrap := range []func(io.Reader) io.Reader{
+				func(r io.Reader) io.Reader { return r },
+				func(r io.Reader) io.Reader { return readerOnly{r} },
+			} {
+				br := bytes.NewReader(append(append([]byte{}, tc.in...), trailer...))
+				v, err := DecodeUint128(wrap(br), tc.enc)
+				require.NoError(t, err)
+				require.Equal(t, u, v, "encoding %d", tc.enc)
+				require.Equal(t, len(trailer), br.Len(), "decoder must not read past the value")
+			}
+		}
+	}
+
+	for _, u := range []Uint128{u64(0), u64(1), u64(127), u64(128), u64(300), u64(maxUint64), u64(1).Lsh(126), MaxUint128} {
+		check(u)
+	}
+	for i := 0; i < 1000; i++ {
+		check(randUint128(bts))
+	}
+
+	require.Len(t, appendUvarint128(nil, MaxUint128), MaxVarintLen128)
+}
+
+func TestDecodeUint128Errors(t *testing.T) {
+	for _, enc := range []Encoding{EncodingBigEndian, EncodingLittleEndian, EncodingUvarint} {
+		_, err := DecodeUint128(bytes.NewReader(nil), enc)
+		require.Equal(t, io.EOF, err, "encoding %d", enc)
+	}
+
+	for _, enc := range []Encoding{EncodingBigEndian, EncodingLittleEndian} {
+		_, err := DecodeUint128(bytes.NewReader(make([]byte, 15)), enc)
+		require.True(t, errors.Is(err, io.ErrUnexpectedEOF), "encoding %d: %v", enc, err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		in        []byte
+		truncated bool
+	}{
+		{"truncated", []byte{0x80}, true},
+		{"truncated-long", bytes.Repeat([]byte{0xff}, 18), true},
+		{"overlong", []byte{0x80, 0x00}, false},
+		{"overlong-one", []byte{0x81, 0x80, 0x00}, false},
+		{"overflow-last-byte", append(bytes.Repeat([]byte{0xff}, 18), 0x04), false},
+		{"overflow-too-long", bytes.Repeat([]byte{0xff}, 20), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecodeUint128(bytes.NewReader(tc.in), EncodingUvarint)
+			require.Error(t, err)
+			require.Equal(t, tc.truncated, errors.Is(err, io.ErrUnexpectedEOF), "%v", err)
+		})
+	}
+
+	_, err := DecodeUint128(bytes.NewReader(make([]byte, 16)), Encoding(99))
+	require.Error(t, err)
+}
+
+func TestDecodeInt128(t *testing.T) {
+	for _, tc := range []struct {
+		i      Int128
+		zigzag Uint128
+	}{
+		{i64(0), u64(0)},
+		{i64(-1), u64(1)},
+		{i64(1), u64(2)},
+		{i64(-2), u64(3)},
+		{MaxInt128, MaxUint128.Dec()},
+		{MinInt128, MaxUint128},
+	} {
+		v, err := DecodeInt128(bytes.NewReader(appendUvarint128(nil, tc.zigzag)), EncodingUvarint)
+		require.NoError(t, err)
+		require.Equal(t, tc.i, v)
+
+		var be, le [16]byte
+		tc.i.AsUint128().PutBigEndian(be[:])
+		tc.i.AsUint128().PutLittleEndian(le[:])
+		v, err = DecodeInt128(bytes.NewReader(be[:]), EncodingBigEndian)
+		require.NoError(t, err)
+		require.Equal(t, tc.i, v)
+		v, err = DecodeInt128(bytes.NewReader(le[:]), EncodingLittleEndian)
+		require.NoError(t, err)
+		require.Equal(t, tc.i, v)
+	}
+
+	_, err := DecodeInt128(bytes.NewReader([]byte{0xff}), EncodingBigEndian)
+	require.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+func TestParseUint128Decimal(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		out Uint128
+		ok  bool
+	}{
+		{"0", u64(0), true},
+		{"00000000000000000000000000000000000000000001", u64(1), true},
+		{"18446744073709551615", u64(maxUint64), true},
+		{"18446744073709551616", u64(1).Lsh(64), true},
+		{"9999999999999999999", u64(9999999999999999999), true},
+		{"340282366920938463463374607431768211455", MaxUint128, true},
+		{"340282366920938463463374607431768211456", zeroUint128, false},
+		{"999999999999999999999999999999999999999", zeroUint128, false},
+		{"3402823669209384634633746074317682114550", zeroUint128, false},
+		{"", zeroUint128, false},
+		{"-1", zeroUint128, false},
+		{"+1", zeroUint128, false},
+		{"0x1", zeroUint128, false},
+		{"1_000", zeroUint128, false},
+		{" 1", zeroUint128, false},
+		{"12345678901234567890a", zeroUint128, false},
+	} {
+		out, err := parseUint128Decimal(tc.in)
+		require.Equal(t, tc.ok, err == nil, "%q: %v", tc.in, err)
+		require.Equal(t, tc.out, out, "%q", tc.in)
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randUint128(bts)
+		out, err := parseUint128Decimal(u.String())
+		require.NoError(t, err)
+		require.Equal(t, u, out)
+	}
+}
+
+func TestParseInt128Decimal(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		out Int128
+		ok  bool
+	}{
+		{"0", i64(0), true},
+		{"-0", i64(0), true},
+		{"+7", i64(7), true},
+		{"-7", i64(-7), true},
+		{"170141183460469231731687303715884105727", MaxInt128, true},
+		{"170141183460469231731687303715884105728", zeroInt128, false},
+		{"-170141183460469231731687303715884105728", MinInt128, true},
+		{"-170141183460469231731687303715884105729", zeroInt128, false},
+		{"-", zeroInt128, false},
+		{"--1", zeroInt128, false},
+		{"", zeroInt128, false},
+	} {
+		out, err := parseInt128Decimal(tc.in)
+		require.Equal(t, tc.ok, err == nil, "%q: %v", tc.in, err)
+		require.Equal(t, tc.out, out, "%q", tc.in)
+	}
+}
+
+func TestParseUint128Lines(t *testing.T) {
+	in := "1\n18446744073709551616\r\n\n  340282366920938463463374607431768211455  \n42"
+	var got []Uint128
+	require.NoError(t, ParseUint128Lines(strings.NewReader(in), func(u Uint128) error {
+		got = append(got, u)
+		return nil
+	}))
+	require.Equal(t, []Uint128{u64(1), u64(1).Lsh(64), MaxUint128, u64(42)}, got)
+
+	// A malformed line stops parsing and reports its line number:
+	got = nil
+	err := ParseUint128Lines(strings.NewReader("1\n2\n\nbogus\n5\n"), func(u Uint128) error {
+		got = append(got, u)
+		return nil
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 4")
+	require.Contains(t, err.Error(), "bogus")
+	require.Equal(t, []Uint128{u64(1), u64(2)}, got)
+
+	err = ParseUint128Lines(strings.NewReader("1\n340282366920938463463374607431768211456\n"), func(u Uint128) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	// Errors from fn are passed through, wrapped with the line:
+	stop := errors.New("stop")
+	err = ParseUint128Lines(strings.NewReader("1\n2\n3\n"), func(u Uint128) error {
+		if u.Equal64(2) {
+			return stop
+		}
+		return nil
+	})
+	require.True(t, errors.Is(err, stop))
+	require.Contains(t, err.Error(), "line 2")
+
+	require.NoError(t, ParseUint128Lines(strings.NewReader(""), func(u Uint128) error {
+		t.Fatal("unexpected value", u)
+		return nil
+	}))
+}
+
+func TestParseInt128Lines(t *testing.T) {
+	in := "-1\n0\n+5\n-170141183460469231731687303715884105728\n170141183460469231731687303715884105727\n"
+	var got []Int128
+	require.NoError(t, ParseInt128Lines(strings.NewReader(in), func(i Int128) error {
+		got = append(got, i)
+		return nil
+	}))
+	require.Equal(t, []Int128{i64(-1), i64(0), i64(5), MinInt128, MaxInt128}, got)
+
+	err := ParseInt128Lines(strings.NewReader("-1\n-\n"), func(i Int128) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	err = ParseInt128Lines(strings.NewReader("1\n2\n170141183460469231731687303715884105728\n"), func(i Int128) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 3")
+}
+
+func TestGob128(t *testing.T) {
+	type record struct {
+		Name     string
+		Unsigned Uint128
+		Signed   Int128
+		Many     []Int128
+		Ptr      *Uint128
+	}
+
+	bts := make([]byte, 16)
+	max := MaxUint128
+	in := []record{
+		{"zero", u64(0), i64(0), nil, nil},
+		{"limits", MaxUint128, MinInt128, []Int128{MaxInt128, i64(-1)}, &max},
+		{"random", randUint128(bts), randInt128(bts), []Int128{randInt128(bts)}, nil},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out []record
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	require.Equal(t, in, out)
+}
+
+func TestGob128Errors(t *testing.T) {
+	var u Uint128
+	require.Error(t, u.GobDecode(make([]byte, 15)))
+
+	var i Int128
+	require.Error(t, i.GobDecode(make([]byte, 17)))
+	require.NoError(t, i.GobDecode(bytes.Repeat([]byte{0xff}, 16)))
+	require.Equal(t, i64(-1), i)
+}
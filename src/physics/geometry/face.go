@@ -34,3 +34,17 @@ func (f *Face) init(a, b, c *Vertex) {
 func (f *Face) GetNormal() Point64 {
 	return f.Dir0.Cross32(f.Dir1)
 }
+
+// side returns the sign of the distance of p from the plane of f, which is
+// positive on the side its normal points to.
+func (f *Face) side(p *Point32) int {
+	d := p.Subtract(f.Origin)
+	o := d.Dot64(f.GetNormal())
+	switch {
+	case o > 0:
+		return 1
+	case o < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,54 @@
+package geometry
+
+// Uint128MinHeap is a min-heap of Uint128 values for use with container/heap.
+// Ordering uses the exact 128-bit comparison, so the smallest value is always
+// at index 0:
+//
+//	h := &Uint128MinHeap{}
+//	heap.Push(h, deadline)
+//	next := heap.Pop(h).(Uint128)
+type Uint128MinHeap []Uint128
+
+func (h Uint128MinHeap) Len() int           { return len(h) }
+func (h Uint128MinHeap) Less(i, j int) bool { return h[i].LessThan(h[j]) }
+func (h Uint128MinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+// Push appends x, which must be a Uint128. Use heap.Push rather than calling
+// this directly.
+func (h *Uint128MinHeap) Push(x any) {
+	*h = append(*h, x.(Uint128))
+}
+
+// Pop removes and returns the last element as a Uint128. Use heap.Pop rather
+// than calling this directly.
+func (h *Uint128MinHeap) Pop() any {
+	old := *h
+	n := len(old)
+	v := old[n-1]
+	*h = old[:n-1]
+	return v
+}
+
+// Int128MinHeap is a min-heap of Int128 values for use with container/heap,
+// ordered by signed 128-bit comparison.
+type Int128MinHeap []Int128
+
+func (h Int128MinHeap) Len() int           { return len(h) }
+func (h Int128MinHeap) Less(i, j int) bool { return h[i].LessThan(h[j]) }
+func (h Int128MinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+// Push appends x, which must be an Int128. Use heap.Push rather than calling
+// this directly.
+func (h *Int128MinHeap) Push(x any) {
+	*h = append(*h, x.(Int128))
+}
+
+// Pop removes and returns the last element as an Int128. Use heap.Pop rather
+// than calling this directly.
+func (h *Int128MinHeap) Pop() any {
+	old := *h
+	n := len(old)
+	v := old[n-1]
+	*h = old[:n-1]
+	return v
+}
@@ -0,0 +1,51 @@
+package geometry
+
+import (
+	"container/heap"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint128MinHeap(t *testing.T) {
+	bts := make([]byte, 16)
+	var in []Uint128
+	for i := 0; i < 500; i++ {
+		v := randUint128(bts)
+		in = append(in, v, v) // duplicates
+	}
+	in = append(in, MaxUint128, MaxUint128, u64(0), u64(maxUint64), u128s("18446744073709551616"))
+
+	h := &Uint128MinHeap{}
+	for _, v := range in {
+		heap.Push(h, v)
+	}
+
+	sort.Slice(in, func(i, j int) bool { return in[i].Cmp(in[j]) < 0 })
+	for _, expected := range in {
+		require.Equal(t, expected, heap.Pop(h).(Uint128))
+	}
+	require.Equal(t, 0, h.Len())
+}
+
+func TestInt128MinHeap(t *testing.T) {
+	bts := make([]byte, 16)
+	var in []Int128
+	for i := 0; i < 500; i++ {
+		v := randInt128(bts)
+		in = append(in, v, v)
+	}
+	in = append(in, MaxInt128, MinInt128, MinInt128, i64(-1), i64(0), i64(1))
+
+	h := &Int128MinHeap{}
+	for _, v := range in {
+		heap.Push(h, v)
+	}
+
+	sort.Slice(in, func(i, j int) bool { return in[i].Cmp(in[j]) < 0 })
+	for _, expected := range in {
+		require.Equal(t, expected, heap.Pop(h).(Int128))
+	}
+	require.Equal(t, 0, h.Len())
+}
@@ -44,12 +44,25 @@ func Int128FromInt(v int) Int128     { return Int128FromInt64(Int64(v)) }
 func Int128FromUint64(v Uint64) Int128 { return Int128{lo: v} }
 
 // Int128FromString creates a Int128 from a string. Overflow truncates to
-// MaxInt128/MinInt128 and sets accurate to 'false'. Only decimal strings are
-// currently supported.
+// MaxInt128/MinInt128 and sets accurate to 'false'. The string may start with a
+// '+' or '-' sign, and then a Go-style base prefix: "0x" or "0X" for hex, "0o",
+// "0O" or "0" for octal, or "0b" or "0B" for binary, as in "-0xFF".
+// Otherwise it's decimal. As in Go source, underscores may separate digits, as
+// in "-1_000_000", but can't lead, trail or be doubled.
 func Int128FromString(s string) (out Int128, accurate bool, err error) {
-	// This deliberately limits the scope of what we accept as input just in case
-	// we decide to hand-roll our own fast decimal-only parser:
-	b, ok := new(big.Int).SetString(s, 10)
+	return Int128FromBase(s, 0)
+}
+
+// Int128FromBase creates a Int128 from a string in the given base, which must
+// be between 2 and 62, or 0 to detect the base from the prefix like
+// Int128FromString. An optional leading '+' or '-' sign is honored in any base,
+// and the digits are interpreted as by big.Int.SetString. Overflow truncates to
+// MaxInt128/MinInt128 and sets accurate to 'false'.
+func Int128FromBase(s string, base int) (out Int128, accurate bool, err error) {
+	if base != 0 && (base < 2 || base > big.MaxBase) {
+		return out, false, fmt.Errorf("num: unsupported base %d", base)
+	}
+	b, ok := new(big.Int).SetString(s, base)
 	if !ok {
 		return out, false, fmt.Errorf("num: Int128 string %q invalid", s)
 	}
@@ -57,6 +70,31 @@ func Int128FromString(s string) (out Int128, accurate bool, err error) {
 	return out, accurate, nil
 }
 
+// parseInt128Decimal is the signed counterpart of parseUint128Decimal. A single
+// leading '-' or '+' is accepted.
+func parseInt128Decimal(s string) (out Int128, err error) {
+	neg := false
+	digits := s
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		neg = digits[0] == '-'
+		digits = digits[1:]
+	}
+	mag, err := parseUint128Decimal(digits)
+	if err != nil {
+		return out, fmt.Errorf("num: Int128 string %q invalid", s)
+	}
+	if neg {
+		if mag.GreaterThan(minInt128AsUint128) {
+			return out, fmt.Errorf("num: Int128 string %q out of range", s)
+		}
+		return mag.AsInt128().Neg(), nil
+	}
+	if mag.GreaterOrEqualTo(minInt128AsUint128) {
+		return out, fmt.Errorf("num: Int128 string %q out of range", s)
+	}
+	return mag.AsInt128(), nil
+}
+
 func MustInt128FromString(s string) Int128 {
 	out, inRange, err := Int128FromString(s)
 	if err != nil {
@@ -169,8 +207,9 @@ func MustInt128FromFloat32(f float32) Int128 {
 // NaN is treated as 0, inRange is set to false. This may change to a panic
 // at some point.
 func Int128FromFloat64(f float64) (out Int128, inRange bool) {
-	const spillPos = float64(maxUint64) // (1<<64) - 1
-	const spillNeg = -float64(maxUint64) - 1
+	// maxUint64 rounds up to 1<<64 as a float64, so these bounds are exclusive:
+	const spillPos = float64(maxUint64)  // 1<<64
+	const spillNeg = -float64(maxUint64) // -(1<<64)
 
 	if f == 0 {
 		return out, true
@@ -179,20 +218,22 @@ func Int128FromFloat64(f float64) (out Int128, inRange bool) {
 		return out, false
 
 	} else if f < 0 {
-		if f >= spillNeg {
-			return Int128{hi: maxUint64, lo: Uint64(f)}, true
+		// Converting a negative float to an unsigned integer is
+		// implementation-defined, so convert the magnitude and negate that:
+		if f > spillNeg {
+			return Int128{lo: Uint64(-f)}.Neg(), true
 		} else if f >= minInt128Float {
 			f = -f
-			lo := math.Mod(f, wrapUint64Float) // f is guaranteed to be < 0 here.
-			return Int128{hi: ^Uint64(f / wrapUint64Float), lo: ^Uint64(lo)}, true
+			lo := math.Mod(f, wrapUint64Float) // f is guaranteed to be > 0 here.
+			return Int128{hi: Uint64(f / wrapUint64Float), lo: Uint64(lo)}.Neg(), true
 		} else {
 			return MinInt128, false
 		}
 
 	} else {
-		if f <= spillPos {
+		if f < spillPos {
 			return Int128{lo: Uint64(f)}, true
-		} else if f <= maxInt128Float {
+		} else if f < maxInt128Float {
 			lo := math.Mod(f, wrapUint64Float) // f is guaranteed to be > 0 here.
 			return Int128{hi: Uint64(f / wrapUint64Float), lo: Uint64(lo)}, true
 		} else {
@@ -216,9 +257,27 @@ func (i Int128) IsZero() bool { return i.lo == 0 && i.hi == 0 }
 func (i Int128) Raw() (hi Uint64, lo Uint64) { return i.hi, i.lo }
 
 func (i Int128) String() string {
-	// FIXME: This is good enough for now, but not forever.
-	v := i.AsBigInt()
-	return v.String()
+	return i.Text(10)
+}
+
+// Text returns the string representation of i in the given base, which must be
+// between 2 and 36, as with big.Int.Text and strconv.FormatInt: negative values
+// are written as a '-' followed by the magnitude, so MinInt128 in base 16 is
+// "-80000000000000000000000000000000". Text panics if base is out of range.
+//
+// The magnitude is formatted by Uint128.Text, so no big.Int is needed.
+func (i Int128) Text(base int) string {
+	if base < 2 || base > 36 {
+		panic(fmt.Errorf("num: illegal Int128 text base %d", base))
+	}
+	sign, mag := i.SignAbs()
+	return mag.text(base, sign < 0)
+}
+
+// FormatInt128 returns i formatted in the given base; it is the free-function
+// form of Int128.Text, named after strconv.FormatInt.
+func FormatInt128(i Int128, base int) string {
+	return i.Text(base)
 }
 
 func (i *Int128) Scan(state fmt.ScanState, verb rune) error {
@@ -239,7 +298,25 @@ func (i *Int128) Scan(state fmt.ScanState, verb rune) error {
 	return nil
 }
 
+// ScientificString formats i in scientific notation with sigDigits significant
+// digits, like "-1.70e+38". See Uint128.ScientificString for details; the
+// magnitude is formatted exactly, so MinInt128 is supported.
+func (i Int128) ScientificString(sigDigits int) string {
+	sign, mag := i.SignAbs()
+	if sign < 0 {
+		return "-" + mag.ScientificString(sigDigits)
+	}
+	return mag.ScientificString(sigDigits)
+}
+
 func (i Int128) Format(s fmt.State, c rune) {
+	switch c {
+	case 'v':
+		if s.Flag('+') {
+			s = plusVState{s}
+		}
+	}
+
 	// FIXME: This is good enough for now, but not forever.
 	i.AsBigInt().Format(s, c)
 }
@@ -248,13 +325,19 @@ func (i Int128) Format(s fmt.State, c rune) {
 // recycle memory.
 func (i Int128) IntoBigInt(b *big.Int) {
 	neg := i.hi&int128SignBit != 0
-	if i.hi > 0 {
-		b.SetUint64(uint64(i.hi))
-		b.Lsh(b, 64)
+	switch intSize {
+	case 64, 32:
+		b.SetBits(putUint128Words(b.Bits(), i.hi, i.lo, intSize))
+
+	default:
+		if i.hi > 0 {
+			b.SetUint64(uint64(i.hi))
+			b.Lsh(b, 64)
+		}
+		var lo big.Int
+		lo.SetUint64(uint64(i.lo))
+		b.Add(b, &lo)
 	}
-	var lo big.Int
-	lo.SetUint64(uint64(i.lo))
-	b.Add(b, &lo)
 
 	if neg {
 		b.Xor(b, maxBigUint128).Add(b, big1).Neg(b)
@@ -264,19 +347,7 @@ func (i Int128) IntoBigInt(b *big.Int) {
 // AsBigInt allocates a new big.Int and copies this Int128 into it.
 func (i Int128) AsBigInt() (b *big.Int) {
 	b = new(big.Int)
-	neg := i.hi&int128SignBit != 0
-	if i.hi > 0 {
-		b.SetUint64(uint64(i.hi))
-		b.Lsh(b, 64)
-	}
-	var lo big.Int
-	lo.SetUint64(uint64(i.lo))
-	b.Add(b, &lo)
-
-	if neg {
-		b.Xor(b, maxBigUint128).Add(b, big1).Neg(b)
-	}
-
+	i.IntoBigInt(b)
 	return b
 }
 
@@ -295,6 +366,13 @@ func (i Int128) AsBigFloat() (b *big.Float) {
 	return new(big.Float).SetInt(i.AsBigInt())
 }
 
+// AsBigFloatExact returns i as a big.Float with a fixed precision of 128 bits.
+// That is enough for the magnitude of MinInt128, so the result is exact and
+// b.Int(nil) returns i unchanged. See Uint128.AsBigFloatExact.
+func (i Int128) AsBigFloatExact() (b *big.Float) {
+	return new(big.Float).SetPrec(128).SetInt(i.AsBigInt())
+}
+
 func (i Int128) AsFloat64() float64 {
 	if i.hi == 0 {
 		if i.lo == 0 {
@@ -302,7 +380,7 @@ func (i Int128) AsFloat64() float64 {
 		} else {
 			return float64(i.lo)
 		}
-	} else if i.hi == maxUint64 {
+	} else if i.hi == maxUint64 && i.lo != 0 { // lo == 0 is -(1<<64), which doesn't fit in lo
 		return -float64((^i.lo) + 1)
 	} else if i.hi&int128SignBit == 0 {
 		return (float64(i.hi) * maxUint64Float) + float64(i.lo)
@@ -311,6 +389,24 @@ func (i Int128) AsFloat64() float64 {
 	}
 }
 
+// ToQFloat interprets i as a fixed-point number in Q notation with fracBits
+// fractional bits and returns its value, i.e. i / 2^fracBits. Scaling by a power
+// of two is exact, so the result is exact whenever i fits in a float64 mantissa.
+func (i Int128) ToQFloat(fracBits int) float64 {
+	if i.IsInt64() {
+		return math.Ldexp(float64(i.AsInt64()), -fracBits)
+	}
+	return math.Ldexp(i.AsFloat64(), -fracBits)
+}
+
+// Int128FromQFloat converts f to a fixed-point Int128 in Q notation with
+// fracBits fractional bits, i.e. f * 2^fracBits rounded to the nearest integer
+// (halves away from zero). If the result is outside the bounds of an Int128 or
+// f is NaN, inRange is set to false; see Int128FromFloat64.
+func Int128FromQFloat(f float64, fracBits int) (out Int128, inRange bool) {
+	return Int128FromFloat64(math.Round(math.Ldexp(f, fracBits)))
+}
+
 // AsInt64 truncates the Int128 to fit in a int64. Values outside the range will
 // over/underflow. See IsInt64() if you want to check before you convert.
 func (i Int128) AsInt64() int64 {
@@ -437,6 +533,78 @@ func (i Int128) Sub64(n int64) (v Int128) {
 	return v
 }
 
+// AddOverflow returns i + n and reports whether the true sum is outside the
+// Int128 range, which happens only when i and n have the same sign and the
+// wrapped result doesn't. The wrapped result, equal to Add, is returned either
+// way.
+func (i Int128) AddOverflow(n Int128) (v Int128, overflow bool) {
+	v = i.Add(n)
+	return v, (i.hi^v.hi)&(n.hi^v.hi)&int128SignBit != 0
+}
+
+// SubOverflow returns i - n and reports whether the true difference is outside
+// the Int128 range, which happens only when i and n have different signs and
+// the wrapped result doesn't have the sign of i. The wrapped result, equal to
+// Sub, is returned either way.
+func (i Int128) SubOverflow(n Int128) (v Int128, overflow bool) {
+	v = i.Sub(n)
+	return v, (i.hi^n.hi)&(i.hi^v.hi)&int128SignBit != 0
+}
+
+// SaturatingAdd returns i + n, clamped to MaxInt128 or MinInt128 instead of
+// wrapping.
+func (i Int128) SaturatingAdd(n Int128) Int128 {
+	v, overflow := i.AddOverflow(n)
+	if !overflow {
+		return v
+	} else if i.hi&int128SignBit != 0 {
+		return MinInt128
+	}
+	return MaxInt128
+}
+
+// SaturatingSub returns i - n, clamped to MaxInt128 or MinInt128 instead of
+// wrapping.
+func (i Int128) SaturatingSub(n Int128) Int128 {
+	v, overflow := i.SubOverflow(n)
+	if !overflow {
+		return v
+	} else if i.hi&int128SignBit != 0 {
+		return MinInt128
+	}
+	return MaxInt128
+}
+
+// MulOverflow returns i * n and reports whether the true product is outside
+// the Int128 range. The wrapped result, equal to Mul, is returned either way.
+//
+// The range is asymmetric: a negative product may have a magnitude of up to
+// 2^127, but a positive one only 2^127-1, so MinInt128 * 1 is fine but
+// MinInt128 * -1 overflows.
+func (i Int128) MulOverflow(n Int128) (v Int128, overflow bool) {
+	isign, imag := i.SignAbs()
+	nsign, nmag := n.SignAbs()
+	mag, overflow := imag.MulOverflow(nmag)
+	if isign*nsign < 0 {
+		overflow = overflow || mag.GreaterThan(minInt128AsAbsUint128)
+	} else {
+		overflow = overflow || mag.GreaterThan(maxInt128AsUint128)
+	}
+	return i.Mul(n), overflow
+}
+
+// SaturatingMul returns i * n, clamped to MaxInt128 or MinInt128 instead of
+// wrapping. See MulOverflow for the limits.
+func (i Int128) SaturatingMul(n Int128) Int128 {
+	v, overflow := i.MulOverflow(n)
+	if !overflow {
+		return v
+	} else if (i.hi^n.hi)&int128SignBit != 0 {
+		return MinInt128
+	}
+	return MaxInt128
+}
+
 func (i Int128) Neg() (v Int128) {
 	if i.hi == 0 && i.lo == 0 {
 		return v
@@ -475,6 +643,16 @@ func (i Int128) Abs() Int128 {
 	return i
 }
 
+// AbsOverflow returns the absolute value of i as a signed integer, and
+// whether it overflowed. The only value that overflows is MinInt128, whose
+// magnitude isn't representable; as with Abs, MinInt128 is returned for it.
+//
+// Use Abs if wrapping is acceptable, or AbsUint128 if the result can be
+// unsigned.
+func (i Int128) AbsOverflow() (v Int128, overflow bool) {
+	return i.Abs(), i == MinInt128
+}
+
 // AbsUint128 returns the absolute value of i as an unsigned integer. All
 // values of i are representable using this function, but the type is
 // changed.
@@ -493,6 +671,18 @@ func (i Int128) AbsUint128() Uint128 {
 	return Uint128{hi: i.hi, lo: i.lo}
 }
 
+// SignAbs returns the sign of i (-1, 0 or +1) and its absolute value as an
+// unsigned integer in a single pass. As with AbsUint128, MinInt128 is
+// representable.
+func (i Int128) SignAbs() (sign int, mag Uint128) {
+	if i.hi == 0 && i.lo == 0 {
+		return 0, mag
+	} else if i.hi&int128SignBit == 0 {
+		return 1, Uint128{hi: i.hi, lo: i.lo}
+	}
+	return -1, i.AbsUint128()
+}
+
 // Cmp compares i to n and returns:
 //
 //	< 0 if i <  n
@@ -663,6 +853,26 @@ func (i Int128) LessOrEqualTo64(n int64) bool {
 	return false
 }
 
+// Between reports whether lo <= i <= hi. The bounds are inclusive; if lo > hi,
+// Between always returns false.
+func (i Int128) Between(lo, hi Int128) bool {
+	return i.Cmp(lo) >= 0 && i.Cmp(hi) <= 0
+}
+
+// Clamp returns lo if i < lo, hi if i > hi, and i otherwise. Clamp panics if
+// lo > hi, as there is no value that satisfies both bounds.
+func (i Int128) Clamp(lo, hi Int128) Int128 {
+	if lo.Cmp(hi) > 0 {
+		panic(fmt.Errorf("num: invalid Int128 clamp range [%s, %s]", lo, hi))
+	}
+	if i.Cmp(lo) < 0 {
+		return lo
+	} else if i.Cmp(hi) > 0 {
+		return hi
+	}
+	return i
+}
+
 // Mul returns the product of two Int128s.
 //
 // Overflow should wrap around, as per the Go spec.
@@ -684,6 +894,172 @@ func (i Int128) Mul64(n Int64) Int128 {
 	return Int128{hi, lo}
 }
 
+// MulDiv returns i*mul/div, computed from the full 256-bit product so that
+// the multiplication can't overflow before the division. If div == 0, a
+// division-by-zero run-time panic occurs.
+//
+// The quotient is truncated towards zero, like Quo, and its sign follows the
+// usual rules for the signs of i, mul and div. If it doesn't fit in an Int128,
+// the result wraps like Mul; for example MinInt128.MulDiv(1, -1) is MinInt128.
+func (i Int128) MulDiv(mul, div Int128) Int128 {
+	isign, imag := i.SignAbs()
+	msign, mmag := mul.SignAbs()
+	dsign, dmag := div.SignAbs()
+	q := imag.MulDiv(mmag, dmag).AsInt128()
+	if isign*msign*dsign < 0 {
+		q = q.Neg()
+	}
+	return q
+}
+
+// ShlWrap returns i << n with the same contract as Go's << on signed
+// integers: the two's complement bit pattern is shifted as if unsigned and the
+// result wraps modulo 2^128. Bits shifted past bit 127 are discarded, and bits
+// shifted into bit 127 change the sign, so a negative value can become
+// positive and vice versa. If n >= 128, the result is 0.
+//
+// ShlWrap never reports overflow; it is equivalent to i.AsUint128().Lsh(n)
+// reinterpreted as an Int128.
+func (i Int128) ShlWrap(n uint) Int128 {
+	return i.AsUint128().Lsh(n).AsInt128()
+}
+
+// Lsh returns i << n. The two's complement bit pattern is shifted as if
+// unsigned, so Lsh is the same as ShlWrap; see ShlWrap for the overflow
+// behaviour.
+func (i Int128) Lsh(n uint) Int128 {
+	return i.AsUint128().Lsh(n).AsInt128()
+}
+
+// Rsh returns i >> n as an arithmetic shift, like Go's >> on signed integers:
+// the sign bit is copied into the vacated bits, so -4 >> 1 == -2 and a
+// negative value shifted by 127 or more is -1.
+func (i Int128) Rsh(n uint) (v Int128) {
+	sign := Uint64(int64(i.hi) >> 63)
+	if n == 0 {
+		return i
+	} else if n >= 128 {
+		v.hi, v.lo = sign, sign
+	} else if n >= 64 {
+		v.lo = Uint64(int64(i.hi) >> (n - 64))
+		v.hi = sign
+	} else {
+		v.lo = (i.lo >> n) | (i.hi << (64 - n))
+		v.hi = Uint64(int64(i.hi) >> n)
+	}
+	return v
+}
+
+// Bit returns the value of the n'th bit of the two's complement representation
+// of i. That is, it returns (i>>n)&1, so Bit(127) is 1 if i is negative. The
+// bit index n must be 0 <= n < 128.
+func (i Int128) Bit(n int) uint {
+	return i.AsUint128().Bit(n)
+}
+
+// SetBit returns an Int128 with the n'th bit of i's two's complement
+// representation set to b (0 or 1). Setting bit 127 changes the sign. If b is
+// not 0 or 1, or n is not 0 <= n < 128, SetBit will panic.
+func (i Int128) SetBit(n int, b uint) Int128 {
+	return i.AsUint128().SetBit(n, b).AsInt128()
+}
+
+// OnesCount returns the number of one bits in the two's complement
+// representation of i, not its magnitude: OnesCount of -1 is 128.
+func (i Int128) OnesCount() int {
+	return OnesCount64(i.hi) + OnesCount64(i.lo)
+}
+
+// LeadingZeros returns the number of leading zero bits in the two's complement
+// representation of i, not its magnitude. It is 0 for any negative i, and 128
+// for i == 0.
+func (i Int128) LeadingZeros() uint {
+	return i.AsUint128().LeadingZeros()
+}
+
+// TrailingZeros returns the number of trailing zero bits in the two's
+// complement representation of i. The result is 128 for i == 0. Negation
+// preserves trailing zeros, so this is also the trailing zero count of |i|.
+func (i Int128) TrailingZeros() uint {
+	return i.AsUint128().TrailingZeros()
+}
+
+// RotateLeft returns the value of i's two's complement representation rotated
+// left by (k mod 128) bits, so the sign bit rotates through like any other.
+// To rotate i right by k bits, call i.RotateLeft(-k).
+func (i Int128) RotateLeft(k int) Int128 {
+	return i.AsUint128().RotateLeft(k).AsInt128()
+}
+
+// And returns the bitwise AND of the two's complement representations of i
+// and n, matching big.Int.And.
+func (i Int128) And(n Int128) Int128 {
+	i.hi = i.hi & n.hi
+	i.lo = i.lo & n.lo
+	return i
+}
+
+// And64 is like And, with n sign-extended to 128 bits.
+func (i Int128) And64(n int64) Int128 {
+	i.hi = i.hi & signExtend64(n)
+	i.lo = i.lo & Uint64(n)
+	return i
+}
+
+// AndNot returns the bitwise AND NOT (i &^ n) of the two's complement
+// representations of i and n, matching big.Int.AndNot.
+func (i Int128) AndNot(n Int128) Int128 {
+	i.hi = i.hi &^ n.hi
+	i.lo = i.lo &^ n.lo
+	return i
+}
+
+// Not returns the bitwise complement of i, which in two's complement is
+// -i - 1. Unlike Neg, it never overflows: Not(MinInt128) is MaxInt128.
+func (i Int128) Not() (out Int128) {
+	out.hi = ^i.hi
+	out.lo = ^i.lo
+	return out
+}
+
+// Or returns the bitwise OR of the two's complement representations of i and
+// n, matching big.Int.Or.
+func (i Int128) Or(n Int128) Int128 {
+	i.hi = i.hi | n.hi
+	i.lo = i.lo | n.lo
+	return i
+}
+
+// Or64 is like Or, with n sign-extended to 128 bits.
+func (i Int128) Or64(n int64) Int128 {
+	i.hi = i.hi | signExtend64(n)
+	i.lo = i.lo | Uint64(n)
+	return i
+}
+
+// Xor returns the bitwise XOR of the two's complement representations of i
+// and n, matching big.Int.Xor.
+func (i Int128) Xor(n Int128) Int128 {
+	i.hi = i.hi ^ n.hi
+	i.lo = i.lo ^ n.lo
+	return i
+}
+
+// Xor64 is like Xor, with n sign-extended to 128 bits.
+func (i Int128) Xor64(n int64) Int128 {
+	i.hi = i.hi ^ signExtend64(n)
+	i.lo = i.lo ^ Uint64(n)
+	return i
+}
+
+// signExtend64 returns the hi word of n sign-extended to 128 bits.
+func signExtend64(n int64) Uint64 {
+	if n < 0 {
+		return maxUint64
+	}
+	return 0
+}
+
 // QuoRem returns the quotient q and remainder r for y != 0. If y == 0, a
 // division-by-zero run-time panic occurs.
 //
@@ -692,7 +1068,7 @@ func (i Int128) Mul64(n Int64) Int128 {
 //	q = x/y      with the result truncated to zero
 //	r = x - y*q
 //
-// Uint128 does not support big.Int.DivMod()-style Euclidean division.
+// See DivMod for big.Int.DivMod()-style Euclidean division.
 //
 // Note: dividing MinInt128 by -1 will overflow, returning MinInt128, as
 // per the Go spec (https://golang.org/ref/spec#Integer_operators):
@@ -723,9 +1099,38 @@ func (i Int128) QuoRem(by Int128) (q, r Int128) {
 	return q, r
 }
 
+// DivMod returns the Euclidean quotient and modulus for y != 0, like
+// big.Int.DivMod. If y == 0, a division-by-zero run-time panic occurs.
+//
+// Unlike QuoRem, the modulus m is never negative:
+//
+//	q = x div y  such that
+//	m = x - y*q  with 0 <= m < |y|
+//
+// For example, -7 QuoRem 3 is (-2, -1), but -7 DivMod 3 is (-3, 2). The two
+// agree whenever x >= 0.
+//
+// As with QuoRem, dividing MinInt128 by -1 overflows, returning MinInt128.
+func (i Int128) DivMod(by Int128) (q, m Int128) {
+	q, m = i.QuoRem(by)
+	if m.hi&int128SignBit != 0 {
+		if by.hi&int128SignBit == 0 {
+			q, m = q.Dec(), m.Add(by)
+		} else {
+			q, m = q.Inc(), m.Sub(by)
+		}
+	}
+	return q, m
+}
+
+// QuoRem64 is like QuoRem, but with an int64 divisor. As with QuoRem, dividing
+// MinInt128 by -1 overflows, returning a quotient of MinInt128 and a remainder
+// of 0.
 func (i Int128) QuoRem64(by int64) (q, r Int128) {
 	ineg := i.hi&int128SignBit != 0
 	if ineg {
+		// i.Neg() is MinInt128 for MinInt128, which is still the right
+		// magnitude when read as unsigned; likewise -by for minInt64 below.
 		i = i.Neg()
 	}
 	byneg := by < 0
@@ -771,6 +1176,8 @@ func (i Int128) Quo(by Int128) (q Int128) {
 	return q
 }
 
+// Quo64 is like Quo, but with an int64 divisor. Dividing MinInt128 by -1
+// overflows, returning MinInt128.
 func (i Int128) Quo64(by int64) (q Int128) {
 	ineg := i.hi&int128SignBit != 0
 	if ineg {
@@ -804,6 +1211,7 @@ func (i Int128) Rem(by Int128) (r Int128) {
 	return r
 }
 
+// Rem64 is like Rem, but with an int64 divisor. MinInt128 % -1 is 0.
 func (i Int128) Rem64(by int64) (r Int128) {
 	ineg := i.hi&int128SignBit != 0
 	if ineg {
@@ -826,14 +1234,75 @@ func (i Int128) Rem64(by int64) (r Int128) {
 	return r
 }
 
+// Pow returns i**exp, wrapping on overflow like Go's native integers. A
+// negative base with an odd exponent gives a negative result. Any i**0 is 1,
+// including 0**0, as in big.Int.Exp and math.Pow.
+func (i Int128) Pow(exp uint) Int128 {
+	// Two's complement multiplication wraps the same way regardless of sign,
+	// so the sign takes care of itself:
+	out := Int128{lo: 1}
+	for ; exp != 0; exp >>= 1 {
+		if exp&1 != 0 {
+			out = out.Mul(i)
+		}
+		i = i.Mul(i)
+	}
+	return out
+}
+
+// Sqrt returns floor(sqrt(i)) for i >= 0. The root of any non-negative Int128
+// fits in 64 bits, so this defers to Uint128.Sqrt. If i < 0, Sqrt will panic.
+func (i Int128) Sqrt() Int128 {
+	if i.hi&int128SignBit != 0 {
+		panic(fmt.Errorf("num: square root of negative Int128 %s", i))
+	}
+	return i.AsUint128().Sqrt().AsInt128()
+}
+
 func (i Int128) MarshalText() ([]byte, error) {
-	return []byte(i.String()), nil
+	return i.MarshalTextBase(TextMarshalBase)
 }
 
+// MarshalTextBase is like MarshalText, but formats i in the given base rather
+// than TextMarshalBase. Negative values put the sign before the prefix, as in
+// "-0xff".
+func (i Int128) MarshalTextBase(base int) ([]byte, error) {
+	if base == 10 {
+		return []byte(i.String()), nil
+	}
+	prefix, err := textBasePrefix(base)
+	if err != nil {
+		return nil, err
+	}
+	sign, mag := i.SignAbs()
+	if sign < 0 {
+		prefix = "-" + prefix
+	}
+	return []byte(prefix + mag.Text(base)), nil
+}
+
+// UnmarshalText accepts decimal, or any of the prefixed forms written by
+// MarshalTextBase. Values outside the range of an Int128 are an error.
 func (i *Int128) UnmarshalText(bts []byte) (err error) {
-	v, _, err := Int128FromString(string(bts))
+	s := string(bts)
+	if hasTextBasePrefix(s) {
+		b, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return fmt.Errorf("num: Int128 string %q invalid", s)
+		}
+		v, inRange := Int128FromBigInt(b)
+		if !inRange {
+			return fmt.Errorf("num: Int128 string %q out of range", s)
+		}
+		*i = v
+		return nil
+	}
+
+	v, inRange, err := Int128FromBase(s, 10)
 	if err != nil {
 		return err
+	} else if !inRange {
+		return fmt.Errorf("num: Int128 string %q out of range", s)
 	}
 	*i = v
 	return nil
@@ -856,7 +1325,8 @@ func (i *Int128) UnmarshalJSON(bts []byte) (err error) {
 		bts = bts[1 : ln-1]
 	}
 
-	v, _, err := Int128FromString(string(bts))
+	// JSON numbers are always decimal, so "010" is ten, not eight:
+	v, _, err := Int128FromBase(string(bts), 10)
 	if err != nil {
 		return err
 	}
@@ -864,6 +1334,49 @@ func (i *Int128) UnmarshalJSON(bts []byte) (err error) {
 	return nil
 }
 
+// GobEncode implements gob.GobEncoder. Without it, encoding/gob would silently
+// drop the unexported fields. The encoding is the 16-byte big-endian two's
+// complement form.
+func (i Int128) GobEncode() ([]byte, error) {
+	b := make([]byte, 16)
+	i.PutBigEndian(b)
+	return b, nil
+}
+
+// GobDecode implements gob.GobDecoder, accepting exactly the 16 bytes written
+// by GobEncode.
+func (i *Int128) GobDecode(b []byte) error {
+	if len(b) != 16 {
+		return fmt.Errorf("num: Int128 gob data must be 16 bytes, found %d", len(b))
+	}
+	*i = MustInt128FromBigEndian(b)
+	return nil
+}
+
+// Put big-endian encoded bytes representing this Int128 into byte slice b, in
+// two's complement. len(b) must be >= 16.
+func (i Int128) PutBigEndian(b []byte) {
+	i.AsUint128().PutBigEndian(b)
+}
+
+// Decode 16 bytes of two's complement as a big-endian Int128. Panics if
+// len(b) < 16.
+func MustInt128FromBigEndian(b []byte) Int128 {
+	return MustUint128FromBigEndian(b).AsInt128()
+}
+
+// Put little-endian encoded bytes representing this Int128 into byte slice b,
+// in two's complement. len(b) must be >= 16.
+func (i Int128) PutLittleEndian(b []byte) {
+	i.AsUint128().PutLittleEndian(b)
+}
+
+// Decode 16 bytes of two's complement as a little-endian Int128. Panics if
+// len(b) < 16.
+func MustInt128FromLittleEndian(b []byte) Int128 {
+	return MustUint128FromLittleEndian(b).AsInt128()
+}
+
 // DifferenceInt128 subtracts the smaller of a and b from the larger.
 func DifferenceInt128(a, b Int128) Int128 {
 	if a.hi > b.hi {
@@ -877,3 +1390,43 @@ func DifferenceInt128(a, b Int128) Int128 {
 	}
 	return Int128{}
 }
+
+// AbsInt128 is the free-function form of Int128.Abs, for use where a function
+// value is needed. AbsInt128(MinInt128) == MinInt128.
+func AbsInt128(i Int128) Int128 { return i.Abs() }
+
+// SignInt128 is the free-function form of Int128.Sign. It returns -1 if i < 0,
+// 0 if i == 0 and +1 if i > 0.
+func SignInt128(i Int128) int { return i.Sign() }
+
+// SmallestInt128 returns the smallest of vs by signed order, and panics if vs is
+// empty.
+//
+// It isn't called MinInt128 as that name is taken by the constant.
+func SmallestInt128(vs ...Int128) Int128 {
+	if len(vs) == 0 {
+		panic("num: SmallestInt128 called with no values")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		if v.Cmp(out) < 0 {
+			out = v
+		}
+	}
+	return out
+}
+
+// LargestInt128 returns the largest of vs by signed order, and panics if vs is
+// empty.
+func LargestInt128(vs ...Int128) Int128 {
+	if len(vs) == 0 {
+		panic("num: LargestInt128 called with no values")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		if v.Cmp(out) > 0 {
+			out = v
+		}
+	}
+	return out
+}
@@ -58,6 +58,48 @@ func BenchmarkBigIntDiv(b *testing.B) {
 	}
 }
 
+// intoBigIntShift is the shift-and-add Int128.IntoBigInt that predates the
+// SetBits path; it is kept here as a baseline for BenchmarkInt128IntoBigInt.
+func intoBigIntShift(i Int128, b *big.Int) {
+	b.SetUint64(0)
+	if i.hi > 0 {
+		b.SetUint64(uint64(i.hi))
+		b.Lsh(b, 64)
+	}
+	var lo big.Int
+	lo.SetUint64(uint64(i.lo))
+	b.Add(b, &lo)
+
+	if i.hi&int128SignBit != 0 {
+		b.Xor(b, maxBigUint128).Add(b, big1).Neg(b)
+	}
+}
+
+func BenchmarkInt128IntoBigInt(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		v    Int128
+	}{
+		{"small", i64(12345)},
+		{"max", MaxInt128},
+		{"neg", i64(-12345)},
+		{"min", MinInt128},
+	} {
+		b.Run("words/"+tc.name, func(b *testing.B) {
+			var v big.Int
+			for i := 0; i < b.N; i++ {
+				tc.v.IntoBigInt(&v)
+			}
+		})
+		b.Run("shift/"+tc.name, func(b *testing.B) {
+			var v big.Int
+			for i := 0; i < b.N; i++ {
+				intoBigIntShift(tc.v, &v)
+			}
+		})
+	}
+}
+
 func BenchmarkBigIntCmpEqual(b *testing.B) {
 	var v1, v2 big.Int
 	v1.SetUint64(maxUint64)
@@ -67,3 +109,44 @@ func BenchmarkBigIntCmpEqual(b *testing.B) {
 		benchIntResult = v1.Cmp(&v2)
 	}
 }
+
+// BenchmarkString128 compares String on both 128-bit types against formatting
+// the same value through big.Int, which both types used to do. New formatters
+// should be added to the "formatters" table so they are measured against the
+// same values.
+func BenchmarkString128(b *testing.B) {
+	values := []struct {
+		name string
+		v    Uint128
+	}{
+		{"zero", u64(0)},
+		{"lo32", u64(0xfedcba98)},
+		{"lo64", u64(0xfedcba9876543210)},
+		{"hi32", u128s("0xfedcba98 76543210fedcba98")},
+		{"hi63", u128s("0x7edcba9876543210 fedcba9876543210")},
+		{"max", MaxUint128},
+	}
+
+	formatters := []struct {
+		name string
+		fn   func(u Uint128) string
+	}{
+		{"u128", func(u Uint128) string { return u.String() }},
+		{"i128", func(u Uint128) string { return u.AsInt128().String() }},
+		{"big", func(u Uint128) string { return u.AsBigInt().String() }},
+		{"u128hex", func(u Uint128) string { return u.Text(16) }},
+		{"bighex", func(u Uint128) string { return u.AsBigInt().Text(16) }},
+		{"i128hex", func(u Uint128) string { return u.AsInt128().Text(16) }},
+	}
+
+	for _, f := range formatters {
+		for _, v := range values {
+			b.Run(f.name+"/"+v.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					benchStringResult = f.fn(v.v)
+				}
+			})
+		}
+	}
+}
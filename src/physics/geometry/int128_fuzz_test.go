@@ -34,6 +34,11 @@ type fuzzType string
 // This is the equivalent of passing -num.fuzziter=<...> to 'go test':
 const fuzzDefaultIterations = 20000
 
+// fuzzMaxExponent bounds the exponents used by the pow op. Anything much
+// bigger than this overflows for all but the smallest bases, so the wrapped
+// result stops telling us anything useful:
+const fuzzMaxExponent = 16
+
 // These ops are all enabled by default. You can instead pass them explicitly
 // on the command line like so: '-num.fuzzop=add -num.fuzzop=sub', or you can
 // use the short form '-num.fuzzop=add,sub,mul'.
@@ -44,6 +49,7 @@ const (
 	fuzzAbs                fuzzOp = "abs"
 	fuzzAdd                fuzzOp = "add"
 	fuzzAdd64              fuzzOp = "add64"
+	fuzzAddOverflow        fuzzOp = "addoverflow"
 	fuzzAnd                fuzzOp = "and"
 	fuzzAnd64              fuzzOp = "and64"
 	fuzzAndNot             fuzzOp = "andnot"
@@ -55,9 +61,12 @@ const (
 	fuzzCmp                fuzzOp = "cmp"
 	fuzzCmp64              fuzzOp = "cmp64"
 	fuzzDec                fuzzOp = "dec"
+	fuzzDivMod             fuzzOp = "divmod"
+	fuzzDivisorQuoRem      fuzzOp = "divisorquorem"
 	fuzzEqual              fuzzOp = "equal"
 	fuzzEqual64            fuzzOp = "equal64"
 	fuzzFromFloat64        fuzzOp = "fromfloat64"
+	fuzzGCD                fuzzOp = "gcd"
 	fuzzGreaterOrEqualTo   fuzzOp = "gte"
 	fuzzGreaterOrEqualTo64 fuzzOp = "gte64"
 	fuzzGreaterThan        fuzzOp = "gt"
@@ -68,12 +77,15 @@ const (
 	fuzzLessThan           fuzzOp = "lt"
 	fuzzLessThan64         fuzzOp = "lt64"
 	fuzzLsh                fuzzOp = "lsh"
+	fuzzModInverse         fuzzOp = "modinverse"
 	fuzzMul                fuzzOp = "mul"
 	fuzzMul64              fuzzOp = "mul64"
+	fuzzMulOverflow        fuzzOp = "muloverflow"
 	fuzzNeg                fuzzOp = "neg"
 	fuzzNot                fuzzOp = "not"
 	fuzzOr                 fuzzOp = "or"
 	fuzzOr64               fuzzOp = "or64"
+	fuzzPow                fuzzOp = "pow"
 	fuzzQuo                fuzzOp = "quo"
 	fuzzQuo64              fuzzOp = "quo64"
 	fuzzQuoRem             fuzzOp = "quorem"
@@ -84,7 +96,9 @@ const (
 	fuzzRsh                fuzzOp = "rsh"
 	fuzzString             fuzzOp = "string"
 	fuzzSetBit             fuzzOp = "setbit"
+	fuzzSqrt               fuzzOp = "sqrt"
 	fuzzSub                fuzzOp = "sub"
+	fuzzSubOverflow        fuzzOp = "suboverflow"
 	fuzzSub64              fuzzOp = "sub64"
 	fuzzXor                fuzzOp = "xor"
 	fuzzXor64              fuzzOp = "xor64"
@@ -113,6 +127,7 @@ var allFuzzOps = []fuzzOp{
 	fuzzAbs,
 	fuzzAdd,
 	fuzzAdd64,
+	fuzzAddOverflow,
 	fuzzAnd,
 	fuzzAnd64,
 	fuzzAndNot,
@@ -124,9 +139,12 @@ var allFuzzOps = []fuzzOp{
 	fuzzCmp,
 	fuzzCmp64,
 	fuzzDec,
+	fuzzDivMod,
+	fuzzDivisorQuoRem,
 	fuzzEqual,
 	fuzzEqual64,
 	fuzzFromFloat64,
+	fuzzGCD,
 	fuzzGreaterOrEqualTo,
 	fuzzGreaterOrEqualTo64,
 	fuzzGreaterThan,
@@ -137,12 +155,15 @@ var allFuzzOps = []fuzzOp{
 	fuzzLessThan,
 	fuzzLessThan64,
 	fuzzLsh,
+	fuzzModInverse,
 	fuzzMul,
 	fuzzMul64,
+	fuzzMulOverflow,
 	fuzzNeg,
 	fuzzNot,
 	fuzzOr,
 	fuzzOr64,
+	fuzzPow,
 	fuzzQuo,
 	fuzzQuo64,
 	fuzzQuoRem,
@@ -152,8 +173,10 @@ var allFuzzOps = []fuzzOp{
 	fuzzRotateLeft,
 	fuzzRsh,
 	fuzzSetBit,
+	fuzzSqrt,
 	fuzzString,
 	fuzzSub,
+	fuzzSubOverflow,
 	fuzzSub64,
 	fuzzXor,
 	fuzzXor64,
@@ -166,6 +189,7 @@ type fuzzOps interface {
 	Abs() error
 	Add() error
 	Add64() error
+	AddOverflow() error
 	And() error
 	And64() error
 	AndNot() error
@@ -177,9 +201,12 @@ type fuzzOps interface {
 	Cmp() error
 	Cmp64() error
 	Dec() error
+	DivMod() error
+	DivisorQuoRem() error
 	Equal() error
 	Equal64() error
 	FromFloat64() error
+	GCD() error
 	GreaterOrEqualTo() error
 	GreaterOrEqualTo64() error
 	GreaterThan() error
@@ -190,12 +217,15 @@ type fuzzOps interface {
 	LessThan() error
 	LessThan64() error
 	Lsh() error
+	ModInverse() error
 	Mul() error
 	Mul64() error
+	MulOverflow() error
 	Neg() error
 	Not() error
 	Or() error
 	Or64() error
+	Pow() error
 	Quo() error
 	Quo64() error
 	QuoRem() error
@@ -205,8 +235,10 @@ type fuzzOps interface {
 	RotateLeft() error
 	Rsh() error
 	SetBit() error
+	Sqrt() error
 	String() error
 	Sub() error
+	SubOverflow() error
 	Sub64() error
 	Xor() error
 	Xor64() error
@@ -318,6 +350,8 @@ func TestFuzz(t *testing.T) {
 					err = fuzzImpl.Add()
 				case fuzzAdd64:
 					err = fuzzImpl.Add64()
+				case fuzzAddOverflow:
+					err = fuzzImpl.AddOverflow()
 				case fuzzAnd:
 					err = fuzzImpl.And()
 				case fuzzAnd64:
@@ -340,12 +374,18 @@ func TestFuzz(t *testing.T) {
 					err = fuzzImpl.Cmp64()
 				case fuzzDec:
 					err = fuzzImpl.Dec()
+				case fuzzDivMod:
+					err = fuzzImpl.DivMod()
+				case fuzzDivisorQuoRem:
+					err = fuzzImpl.DivisorQuoRem()
 				case fuzzEqual:
 					err = fuzzImpl.Equal()
 				case fuzzEqual64:
 					err = fuzzImpl.Equal64()
 				case fuzzFromFloat64:
 					err = fuzzImpl.FromFloat64()
+				case fuzzGCD:
+					err = fuzzImpl.GCD()
 				case fuzzGreaterOrEqualTo:
 					err = fuzzImpl.GreaterOrEqualTo()
 				case fuzzGreaterOrEqualTo64:
@@ -366,10 +406,14 @@ func TestFuzz(t *testing.T) {
 					err = fuzzImpl.LessThan64()
 				case fuzzLsh:
 					err = fuzzImpl.Lsh()
+				case fuzzModInverse:
+					err = fuzzImpl.ModInverse()
 				case fuzzMul:
 					err = fuzzImpl.Mul()
 				case fuzzMul64:
 					err = fuzzImpl.Mul64()
+				case fuzzMulOverflow:
+					err = fuzzImpl.MulOverflow()
 				case fuzzNeg:
 					err = fuzzImpl.Neg()
 				case fuzzNot:
@@ -378,6 +422,8 @@ func TestFuzz(t *testing.T) {
 					err = fuzzImpl.Or()
 				case fuzzOr64:
 					err = fuzzImpl.Or64()
+				case fuzzPow:
+					err = fuzzImpl.Pow()
 				case fuzzQuo:
 					err = fuzzImpl.Quo()
 				case fuzzQuo64:
@@ -396,10 +442,14 @@ func TestFuzz(t *testing.T) {
 					err = fuzzImpl.Rsh()
 				case fuzzSetBit:
 					err = fuzzImpl.SetBit()
+				case fuzzSqrt:
+					err = fuzzImpl.Sqrt()
 				case fuzzString:
 					err = fuzzImpl.String()
 				case fuzzSub:
 					err = fuzzImpl.Sub()
+				case fuzzSubOverflow:
+					err = fuzzImpl.SubOverflow()
 				case fuzzSub64:
 					err = fuzzImpl.Sub64()
 				case fuzzXor:
@@ -463,6 +513,7 @@ func (op fuzzOp) Print(operands ...*big.Int) string {
 		fuzzBinBE,
 		fuzzBinLE,
 		fuzzBitLen,
+		fuzzSqrt,
 		fuzzString:
 		s := strings.TrimRight(op.String(), "()")
 		return fmt.Sprintf("%s(%d)", s, operands[0])
@@ -470,6 +521,12 @@ func (op fuzzOp) Print(operands ...*big.Int) string {
 	case fuzzSetBit:
 		return fmt.Sprintf("%d|(1<<%d)", operands[0], operands[1])
 
+	case fuzzGCD:
+		return fmt.Sprintf("gcd(%d, %d)", operands[0], operands[1])
+
+	case fuzzModInverse:
+		return fmt.Sprintf("modinverse(%d, %d)", operands[0], operands[1])
+
 	case fuzzBit:
 		return fmt.Sprintf("(%b>>%d)&1", operands[0], operands[1])
 
@@ -482,16 +539,18 @@ func (op fuzzOp) Print(operands ...*big.Int) string {
 	case fuzzAbs:
 		return fmt.Sprintf("|%d|", operands[0])
 
-	case fuzzAdd, fuzzAdd64,
+	case fuzzAdd, fuzzAdd64, fuzzAddOverflow,
 		fuzzAnd, fuzzAnd64,
 		fuzzAndNot,
 		fuzzLessOrEqualTo, fuzzLessOrEqualTo64,
 		fuzzLessThan, fuzzLessThan64,
 		fuzzLsh,
-		fuzzMul, fuzzMul64,
+		fuzzMul, fuzzMul64, fuzzMulOverflow,
 		fuzzOr, fuzzOr64,
+		fuzzPow,
 		fuzzQuo, fuzzQuo64,
-		fuzzQuoRem, fuzzQuoRem64,
+		fuzzQuoRem, fuzzQuoRem64, fuzzDivisorQuoRem,
+		fuzzDivMod,
 		fuzzRem, fuzzRem64,
 		fuzzRotateLeft,
 		fuzzRsh,
@@ -500,7 +559,7 @@ func (op fuzzOp) Print(operands ...*big.Int) string {
 		fuzzEqual,
 		fuzzGreaterOrEqualTo, fuzzGreaterOrEqualTo64,
 		fuzzGreaterThan, fuzzGreaterThan64,
-		fuzzSub:
+		fuzzSub, fuzzSubOverflow:
 
 		// simple binary case:
 		return fmt.Sprintf("%d %s %d", operands[0], op.String(), operands[1])
@@ -516,7 +575,7 @@ func (op fuzzOp) String() string {
 	switch op {
 	case fuzzAbs:
 		return "|x|"
-	case fuzzAdd, fuzzAdd64:
+	case fuzzAdd, fuzzAdd64, fuzzAddOverflow:
 		return "+"
 	case fuzzAnd, fuzzAnd64:
 		return "&"
@@ -532,10 +591,14 @@ func (op fuzzOp) String() string {
 		return "<=>"
 	case fuzzDec:
 		return "--"
+	case fuzzDivMod:
+		return "divmod"
 	case fuzzEqual, fuzzEqual64:
 		return "=="
 	case fuzzFromFloat64:
 		return "fromfloat64()"
+	case fuzzGCD:
+		return "gcd()"
 	case fuzzGreaterThan, fuzzGreaterThan64:
 		return ">"
 	case fuzzGreaterOrEqualTo, fuzzGreaterOrEqualTo64:
@@ -548,7 +611,9 @@ func (op fuzzOp) String() string {
 		return "<="
 	case fuzzLsh:
 		return "<<"
-	case fuzzMul, fuzzMul64:
+	case fuzzModInverse:
+		return "modinverse()"
+	case fuzzMul, fuzzMul64, fuzzMulOverflow:
 		return "*"
 	case fuzzNeg:
 		return "-"
@@ -556,9 +621,11 @@ func (op fuzzOp) String() string {
 		return "^"
 	case fuzzOr:
 		return "|"
+	case fuzzPow:
+		return "**"
 	case fuzzQuo, fuzzQuo64:
 		return "/"
-	case fuzzQuoRem, fuzzQuoRem64:
+	case fuzzQuoRem, fuzzQuoRem64, fuzzDivisorQuoRem:
 		return "/%"
 	case fuzzRem, fuzzRem64:
 		return "%"
@@ -568,9 +635,11 @@ func (op fuzzOp) String() string {
 		return ">>"
 	case fuzzSetBit:
 		return "setbit()"
+	case fuzzSqrt:
+		return "sqrt()"
 	case fuzzString:
 		return "string()"
-	case fuzzSub, fuzzSub64:
+	case fuzzSub, fuzzSub64, fuzzSubOverflow:
 		return "-"
 	case fuzzXor, fuzzXor64:
 		return "^"
@@ -732,6 +801,29 @@ func (f fuzzUint128) QuoRem() error {
 	return nil
 }
 
+func (f fuzzUint128) DivMod() error {
+	return nil // Uint128 has no DivMod; it would be the same as QuoRem
+}
+
+func (f fuzzUint128) DivisorQuoRem() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	if b2.Cmp(big0) == 0 {
+		return nil // Just skip this iteration, we know what happens!
+	}
+
+	rbq := new(big.Int).Quo(b1, b2)
+	rbr := new(big.Int).Rem(b1, b2)
+	ruq, rur := NewUint128Divisor(u2).QuoRem(u1)
+	if err := checkEqualUint128("quo", ruq, rbq); err != nil {
+		return err
+	}
+	if err := checkEqualUint128("rem", rur, rbr); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (f fuzzUint128) QuoRem64() error {
 	b1, b2 := f.source.BigUint128And64()
 	u1, u2 := accUint128FromBigInt(b1), accU64FromBigInt(b2)
@@ -1057,6 +1149,90 @@ func (f fuzzUint128) BitLen() error {
 	return checkEqualInt(rb, ru)
 }
 
+func (f fuzzUint128) Pow() error {
+	b1, exp := f.source.BigUint128AndExponent()
+	u1 := accUint128FromBigInt(b1)
+	rb := new(big.Int).Exp(b1, new(big.Int).SetUint64(uint64(exp)), nil)
+	rb.And(rb, maxBigUint128)
+	ru := u1.Pow(exp)
+	return checkEqualUint128("pow", ru, rb)
+}
+
+func (f fuzzUint128) Sqrt() error {
+	b1 := f.source.BigUint128()
+	u1 := accUint128FromBigInt(b1)
+	rb := new(big.Int).Sqrt(b1)
+	ru := u1.Sqrt()
+	return checkEqualUint128("sqrt", ru, rb)
+}
+
+func (f fuzzUint128) GCD() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	rb := new(big.Int).GCD(nil, nil, b1, b2)
+	ru := u1.GCD(u2)
+	return checkEqualUint128("gcd", ru, rb)
+}
+
+func (f fuzzUint128) ModInverse() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	if b2.Cmp(big0) == 0 {
+		return nil // Just skip this iteration, we know what happens!
+	}
+
+	ru, ok := u1.ModInverse(u2)
+	rb := new(big.Int).ModInverse(b1, b2)
+	if rb == nil {
+		if ok {
+			return fmt.Errorf("modinverse: expected no inverse, found %s", ru)
+		}
+		return nil
+	} else if !ok {
+		return fmt.Errorf("modinverse: expected %s, found no inverse", rb)
+	}
+	return checkEqualUint128("modinverse", ru, rb)
+}
+
+func (f fuzzUint128) AddOverflow() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	rb := new(big.Int).Add(b1, b2)
+	overflow := rb.Cmp(maxBigUint128) > 0
+	ru, ok := u1.AddOverflow(u2)
+	if ok != overflow {
+		return fmt.Errorf("addoverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualUint128("addoverflow", ru, simulateBigUint128Overflow(rb))
+}
+
+func (f fuzzUint128) SubOverflow() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	rb := new(big.Int).Sub(b1, b2)
+	overflow := rb.Cmp(big0) < 0
+	if overflow {
+		rb = new(big.Int).Add(wrapBigUint128, rb) // simulate underflow
+	}
+	ru, ok := u1.SubOverflow(u2)
+	if ok != overflow {
+		return fmt.Errorf("suboverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualUint128("suboverflow", ru, rb)
+}
+
+func (f fuzzUint128) MulOverflow() error {
+	b1, b2 := f.source.BigUint128x2()
+	u1, u2 := accUint128FromBigInt(b1), accUint128FromBigInt(b2)
+	rb := new(big.Int).Mul(b1, b2)
+	overflow := rb.Cmp(maxBigUint128) > 0
+	ru, ok := u1.MulOverflow(u2)
+	if ok != overflow {
+		return fmt.Errorf("muloverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualUint128("muloverflow", ru, simulateBigUint128Overflow(rb))
+}
+
 // NEWOP: func (f fuzzUint128) ...() error {}
 
 type fuzzInt128 struct {
@@ -1090,6 +1266,72 @@ func (f fuzzInt128) Inc() error {
 	return checkEqualInt128("inc", ru, rb)
 }
 
+func (f fuzzInt128) DivisorQuoRem() error {
+	return nil // Uint128Divisor is unsigned-only
+}
+
+func (f fuzzInt128) Pow() error {
+	b1, exp := f.source.BigInt128AndExponent()
+	i1 := accInt128FromBigInt(b1)
+	rb := new(big.Int).Exp(b1, new(big.Int).SetUint64(uint64(exp)), nil)
+	rb = simulateBigInt128Overflow(rb)
+	ri := i1.Pow(exp)
+	return checkEqualInt128("pow", ri, rb)
+}
+
+func (f fuzzInt128) Sqrt() error {
+	b1 := f.source.BigInt128()
+	b1 = new(big.Int).Abs(b1) // Sqrt panics on negative input
+	i1 := accInt128FromBigInt(b1)
+	rb := new(big.Int).Sqrt(b1)
+	ri := i1.Sqrt()
+	return checkEqualInt128("sqrt", ri, rb)
+}
+
+func (f fuzzInt128) GCD() error {
+	return nil // GCD is unsigned-only
+}
+
+func (f fuzzInt128) ModInverse() error {
+	return nil // ModInverse is unsigned-only
+}
+
+func (f fuzzInt128) AddOverflow() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).Add(b1, b2)
+	overflow := rb.Cmp(maxBigInt128) > 0 || rb.Cmp(minBigInt128) < 0
+	ri, ok := i1.AddOverflow(i2)
+	if ok != overflow {
+		return fmt.Errorf("addoverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualInt128("addoverflow", ri, simulateBigInt128Overflow(rb))
+}
+
+func (f fuzzInt128) SubOverflow() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).Sub(b1, b2)
+	overflow := rb.Cmp(maxBigInt128) > 0 || rb.Cmp(minBigInt128) < 0
+	ri, ok := i1.SubOverflow(i2)
+	if ok != overflow {
+		return fmt.Errorf("suboverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualInt128("suboverflow", ri, simulateBigInt128Overflow(rb))
+}
+
+func (f fuzzInt128) MulOverflow() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).Mul(b1, b2)
+	overflow := rb.Cmp(maxBigInt128) > 0 || rb.Cmp(minBigInt128) < 0
+	ri, ok := i1.MulOverflow(i2)
+	if ok != overflow {
+		return fmt.Errorf("muloverflow: expected overflow %v, found %v", overflow, ok)
+	}
+	return checkEqualInt128("muloverflow", ri, simulateBigInt128Overflow(rb))
+}
+
 func (f fuzzInt128) Dec() error {
 	b1 := f.source.BigInt128()
 	u1 := accInt128FromBigInt(b1)
@@ -1173,12 +1415,8 @@ func (f fuzzInt128) Quo64() error {
 	if b2.Cmp(big0) == 0 {
 		return nil // Just skip this iteration, we know what happens!
 	}
-	if i1 == MinInt128 && i2 == -1 {
-		// Skip overflow corner case, it's (not yet, FIXME) handled in the
-		// unit tests and not meaningful here in the fuzzer.
-		return nil
-	}
 	rb := new(big.Int).Quo(b1, b2)
+	rb = simulateBigInt128Overflow(rb) // MinInt128 / -1
 	ri := i1.Quo64(i2)
 	return checkEqualInt128("quo64", ri, rb)
 }
@@ -1203,11 +1441,6 @@ func (f fuzzInt128) Rem64() error {
 	if b2.Cmp(big0) == 0 {
 		return nil // Just skip this iteration, we know what happens!
 	}
-	if i1 == MinInt128 && i2 == -1 {
-		// Skip overflow corner case, it's (not yet, FIXME) handled in the
-		// unit tests and not meaningful here in the fuzzer.
-		return nil
-	}
 	rb := new(big.Int).Rem(b1, b2)
 	ri := i1.Rem64(i2)
 	return checkEqualInt128("rem64", ri, rb)
@@ -1235,19 +1468,36 @@ func (f fuzzInt128) QuoRem() error {
 	return nil
 }
 
+func (f fuzzInt128) DivMod() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	if b2.Cmp(big0) == 0 {
+		return nil // Just skip this iteration, we know what happens!
+	}
+	if i1 == MinInt128 && i2 == minusOne {
+		return nil // Skip overflow corner case, it's handled in the unit tests and not meaningful here in the fuzzer.
+	}
+
+	rbd, rbm := new(big.Int).DivMod(b1, b2, new(big.Int))
+	rid, rim := i1.DivMod(i2)
+	if err := checkEqualInt128("div", rid, rbd); err != nil {
+		return err
+	}
+	if err := checkEqualInt128("mod", rim, rbm); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (f fuzzInt128) QuoRem64() error {
 	b1, b2 := f.source.BigInt128And64()
 	i1, i2 := accInt128FromBigInt(b1), accI64FromBigInt(b2)
 	if b2.Cmp(big0) == 0 {
 		return nil // Just skip this iteration, we know what happens!
 	}
-	if i1 == MinInt128 && i2 == -1 {
-		// Skip overflow corner case, it's (not yet, FIXME) handled in the
-		// unit tests and not meaningful here in the fuzzer.
-		return nil
-	}
 
 	rbq := new(big.Int).Quo(b1, b2)
+	rbq = simulateBigInt128Overflow(rbq) // MinInt128 / -1
 	rbr := new(big.Int).Rem(b1, b2)
 	riq, rir := i1.QuoRem64(i2)
 	if err := checkEqualInt128("quo64", riq, rbq); err != nil {
@@ -1365,21 +1615,144 @@ func (f fuzzInt128) FromFloat64() error {
 	return nil
 }
 
-// Bitwise operations on Int128 are not supported:
-func (f fuzzInt128) And() error        { return nil }
-func (f fuzzInt128) And64() error      { return nil }
-func (f fuzzInt128) AndNot() error     { return nil }
-func (f fuzzInt128) Or() error         { return nil }
-func (f fuzzInt128) Or64() error       { return nil }
-func (f fuzzInt128) Xor() error        { return nil }
-func (f fuzzInt128) Xor64() error      { return nil }
-func (f fuzzInt128) Lsh() error        { return nil }
-func (f fuzzInt128) Rsh() error        { return nil }
-func (f fuzzInt128) SetBit() error     { return nil }
-func (f fuzzInt128) Bit() error        { return nil }
-func (f fuzzInt128) BitLen() error     { return nil }
-func (f fuzzInt128) Not() error        { return nil }
-func (f fuzzInt128) RotateLeft() error { return nil }
+func (f fuzzInt128) And() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).And(b1, b2)
+	ri := i1.And(i2)
+	return checkEqualInt128("and", ri, rb)
+}
+
+func (f fuzzInt128) And64() error {
+	b1, b2 := f.source.BigInt128And64()
+	i1, i2 := accInt128FromBigInt(b1), accI64FromBigInt(b2)
+	rb := new(big.Int).And(b1, b2)
+	ri := i1.And64(i2)
+	return checkEqualInt128("and64", ri, rb)
+}
+
+func (f fuzzInt128) AndNot() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).AndNot(b1, b2)
+	ri := i1.AndNot(i2)
+	return checkEqualInt128("andnot", ri, rb)
+}
+
+func (f fuzzInt128) Or() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).Or(b1, b2)
+	ri := i1.Or(i2)
+	return checkEqualInt128("or", ri, rb)
+}
+
+func (f fuzzInt128) Or64() error {
+	b1, b2 := f.source.BigInt128And64()
+	i1, i2 := accInt128FromBigInt(b1), accI64FromBigInt(b2)
+	rb := new(big.Int).Or(b1, b2)
+	ri := i1.Or64(i2)
+	return checkEqualInt128("or64", ri, rb)
+}
+
+func (f fuzzInt128) Xor() error {
+	b1, b2 := f.source.BigInt128x2()
+	i1, i2 := accInt128FromBigInt(b1), accInt128FromBigInt(b2)
+	rb := new(big.Int).Xor(b1, b2)
+	ri := i1.Xor(i2)
+	return checkEqualInt128("xor", ri, rb)
+}
+
+func (f fuzzInt128) Xor64() error {
+	b1, b2 := f.source.BigInt128And64()
+	i1, i2 := accInt128FromBigInt(b1), accI64FromBigInt(b2)
+	rb := new(big.Int).Xor(b1, b2)
+	ri := i1.Xor64(i2)
+	return checkEqualInt128("xor64", ri, rb)
+}
+
+func (f fuzzInt128) Lsh() error {
+	b1, by := f.source.BigInt128AndBitSize()
+	i1 := accInt128FromBigInt(b1)
+	rb := new(big.Int).Lsh(b1, by)
+	rb.And(rb, maxBigUint128)
+	if rb.Bit(127) == 1 {
+		rb.Sub(rb, wrapBigUint128)
+	}
+	ri := i1.Lsh(by)
+	return checkEqualInt128("lsh", ri, rb)
+}
+
+func (f fuzzInt128) Rsh() error {
+	b1, by := f.source.BigInt128AndBitSize()
+	i1 := accInt128FromBigInt(b1)
+	rb := new(big.Int).Rsh(b1, by) // big.Int.Rsh is arithmetic for negative values
+	ri := i1.Rsh(by)
+	return checkEqualInt128("rsh", ri, rb)
+}
+
+func (f fuzzInt128) SetBit() error {
+	b1, bt, bv := f.source.BigInt128AndBitSizeAndBitValue()
+	i1 := accInt128FromBigInt(b1)
+
+	bvi := uint(0)
+	if bv {
+		bvi = 1
+	}
+
+	// big.Int treats negative values as infinitely sign-extended, so setting
+	// or clearing bit 127 can take the result out of range:
+	rb := new(big.Int).SetBit(b1, int(bt), bvi)
+	rb = simulateBigInt128Overflow(rb)
+	ri := i1.SetBit(int(bt), bvi)
+	return checkEqualInt128("setbit", ri, rb)
+}
+
+func (f fuzzInt128) Bit() error {
+	// BigInt128AndBitSize goes up to 128 for the shifts, which is out of range
+	// for Bit:
+	b1, bt, _ := f.source.BigInt128AndBitSizeAndBitValue()
+	i1 := accInt128FromBigInt(b1)
+	return checkEqualInt(int(b1.Bit(int(bt))), int(i1.Bit(int(bt))))
+}
+
+// Int128 has no BitLen:
+func (f fuzzInt128) BitLen() error { return nil }
+
+func (f fuzzInt128) RotateLeft() error {
+	b1, by := f.source.BigInt128AndBitSize()
+	i1 := accInt128FromBigInt(b1)
+
+	// Rotate the raw bit pattern as unsigned, then reinterpret the sign:
+	bu := i1.AsUint128().AsBigInt()
+	rb1 := new(big.Int).Lsh(bu, by)
+	rb1.And(rb1, maxBigUint128)
+	rb2 := new(big.Int).Rsh(bu, 128-by)
+	rb1.Or(rb1, rb2)
+	if rb1.Bit(127) == 1 {
+		rb1.Sub(rb1, wrapBigUint128)
+	}
+
+	ri := i1.RotateLeft(int(by))
+	if err := checkEqualInt128("rotl", ri, rb1); err != nil {
+		return err
+	}
+	return checkEqualInt128("rotr", ri.RotateLeft(-int(by)), b1)
+}
+
+func (f fuzzInt128) Not() error {
+	b1 := f.source.BigInt128()
+	i1 := accInt128FromBigInt(b1)
+	ri := i1.Not()
+	if err := checkEqualInt128("notnot", ri.Not(), b1); err != nil {
+		return err
+	}
+	if err := checkEqualInt128("notnegdec", ri, i1.Neg().Dec().AsBigInt()); err != nil {
+		return err
+	}
+	rb := new(big.Int).Not(b1)
+	return checkEqualInt128("not", ri, rb)
+}
 
 func (f fuzzInt128) Neg() error {
 	b1 := f.source.BigInt128()
@@ -1392,13 +1765,60 @@ func (f fuzzInt128) Neg() error {
 	return checkEqualInt128("neg", ru, rb)
 }
 
+// bigInt128Bytes returns the 16-byte big-endian two's complement form of b,
+// which must be in Int128 range.
+func bigInt128Bytes(b *big.Int) []byte {
+	bts := make([]byte, 16)
+	if b.Sign() < 0 {
+		new(big.Int).Add(b, wrapBigUint128).FillBytes(bts)
+	} else {
+		b.FillBytes(bts)
+	}
+	return bts
+}
+
 func (f fuzzInt128) BinBE() error {
-	// Nothing to do
+	b1 := f.source.BigInt128()
+	i1 := accInt128FromBigInt(b1)
+
+	b1bts := bigInt128Bytes(b1)
+
+	i1bts := make([]byte, 16)
+	i1.PutBigEndian(i1bts)
+
+	if err := checkEqualBytes("binbe", b1bts, i1bts); err != nil {
+		return err
+	}
+
+	i2 := MustInt128FromBigEndian(i1bts)
+	if !i1.Equal(i2) || i1.Sign() != i2.Sign() {
+		return fmt.Errorf("binbe: i128(%s) != i128(%s)", i1.String(), i2.String())
+	}
 	return nil
 }
 
 func (f fuzzInt128) BinLE() error {
-	// Nothing to do
+	b1 := f.source.BigInt128()
+	i1 := accInt128FromBigInt(b1)
+
+	b1bts := bigInt128Bytes(b1)
+
+	// big.Int writes big endian; reverse the slice:
+	for i, j := 0, len(b1bts)-1; i < j; i, j = i+1, j-1 {
+		b1bts[i], b1bts[j] = b1bts[j], b1bts[i]
+	}
+
+	i1bts := make([]byte, 16)
+	i1.PutLittleEndian(i1bts)
+
+	if err := checkEqualBytes("binle", b1bts, i1bts); err != nil {
+		return err
+	}
+
+	i2 := MustInt128FromLittleEndian(i1bts)
+	if !i1.Equal(i2) || i1.Sign() != i2.Sign() {
+		return fmt.Errorf("binle: i128(%s) != i128(%s)", i1.String(), i2.String())
+	}
 	return nil
 }
 
@@ -1516,6 +1936,51 @@ func (gen bigUint128AndBitSizeGen) Values(r *rando) (v *big.Int, shift uint) {
 	return val, gen.shift
 }
 
+type bigInt128AndBitSizeGen struct {
+	i128  bigInt128Gen
+	shift uint // 0 to 128
+}
+
+func (gen bigInt128AndBitSizeGen) Values(r *rando) (v *big.Int, shift uint) {
+	v = gen.i128.Value(r)
+	r.operands = append(r.operands, new(big.Int).SetUint64(uint64(gen.shift)))
+	return v, gen.shift
+}
+
+type bigInt128AndBitSizeAndBitValueGen struct {
+	i128  bigInt128Gen
+	shift uint // 0 to 127
+	value bool // 0 or 1
+}
+
+func (gen bigInt128AndBitSizeAndBitValueGen) Values(r *rando) (v *big.Int, shift uint, value bool) {
+	v = gen.i128.Value(r)
+	r.operands = append(r.operands, new(big.Int).SetUint64(uint64(gen.shift)))
+	return v, gen.shift, gen.value
+}
+
+type bigUint128AndExponentGen struct {
+	u128 bigUint128Gen
+	exp  uint // 0 to fuzzMaxExponent
+}
+
+func (gen bigUint128AndExponentGen) Values(r *rando) (v *big.Int, exp uint) {
+	v = gen.u128.Value(r)
+	r.operands = append(r.operands, new(big.Int).SetUint64(uint64(gen.exp)))
+	return v, gen.exp
+}
+
+type bigInt128AndExponentGen struct {
+	i128 bigInt128Gen
+	exp  uint // 0 to fuzzMaxExponent
+}
+
+func (gen bigInt128AndExponentGen) Values(r *rando) (v *big.Int, exp uint) {
+	v = gen.i128.Value(r)
+	r.operands = append(r.operands, new(big.Int).SetUint64(uint64(gen.exp)))
+	return v, gen.exp
+}
+
 type bigUint128AndBitSizeAndBitValueGen struct {
 	u128  bigUint128Gen
 	shift uint // 0 to 127
@@ -1558,6 +2023,18 @@ type rando struct {
 	bigUint128AndBitSizeAndBitValueSchemes []bigUint128AndBitSizeAndBitValueGen
 	bigUint128AndBitSizeAndBitValueCur     int
 
+	bigInt128AndBitSizeSchemes []bigInt128AndBitSizeGen
+	bigInt128AndBitSizeCur     int
+
+	bigInt128AndBitSizeAndBitValueSchemes []bigInt128AndBitSizeAndBitValueGen
+	bigInt128AndBitSizeAndBitValueCur     int
+
+	bigUint128AndExponentSchemes []bigUint128AndExponentGen
+	bigUint128AndExponentCur     int
+
+	bigInt128AndExponentSchemes []bigInt128AndExponentGen
+	bigInt128AndExponentCur     int
+
 	// This test has run; subsequent rando requests should fail until NewTest
 	// is called again:
 	testHasRun bool
@@ -1606,6 +2083,15 @@ func newRando(rng *rand.Rand) *rando {
 		}
 	}
 
+	{ // build bigUint128AndExponentSchemes
+		for _, u := range r.bigUint128Schemes {
+			for exp := uint(0); exp <= fuzzMaxExponent; exp++ {
+				r.bigUint128AndExponentSchemes = append(
+					r.bigUint128AndExponentSchemes, bigUint128AndExponentGen{u128: u, exp: exp})
+			}
+		}
+	}
+
 	{ // build bigUint128x2Schemes
 		for _, u1 := range r.bigUint128Schemes {
 			for _, u2 := range r.bigUint128Schemes {
@@ -1650,6 +2136,35 @@ func newRando(rng *rand.Rand) *rando {
 		}
 	}
 
+	{ // build bigInt128AndBitSizeSchemes
+		for _, i := range r.bigInt128Schemes {
+			for shift := uint(0); shift <= 128; shift++ {
+				r.bigInt128AndBitSizeSchemes = append(
+					r.bigInt128AndBitSizeSchemes, bigInt128AndBitSizeGen{i128: i, shift: shift})
+			}
+		}
+	}
+
+	{ // build bigInt128AndBitSizeAndBitValueSchemes
+		for _, i := range r.bigInt128Schemes {
+			for shift := uint(0); shift < 128; shift++ {
+				for value := 0; value < 2; value++ {
+					r.bigInt128AndBitSizeAndBitValueSchemes = append(
+						r.bigInt128AndBitSizeAndBitValueSchemes, bigInt128AndBitSizeAndBitValueGen{i128: i, shift: shift, value: value == 1})
+				}
+			}
+		}
+	}
+
+	{ // build bigInt128AndExponentSchemes
+		for _, i := range r.bigInt128Schemes {
+			for exp := uint(0); exp <= fuzzMaxExponent; exp++ {
+				r.bigInt128AndExponentSchemes = append(
+					r.bigInt128AndExponentSchemes, bigInt128AndExponentGen{i128: i, exp: exp})
+			}
+		}
+	}
+
 	{ // build bigInt128x2Schemes
 		for _, u1 := range r.bigInt128Schemes {
 			for _, u2 := range r.bigInt128Schemes {
@@ -1696,6 +2211,10 @@ func (r *rando) NextOp(op fuzzOp, configuredIterations int) (opIterations int) {
 	r.bigInt128Cur = 0
 	r.bigUint128AndBitSizeCur = 0
 	r.bigUint128AndBitSizeAndBitValueCur = 0
+	r.bigInt128AndBitSizeCur = 0
+	r.bigInt128AndBitSizeAndBitValueCur = 0
+	r.bigUint128AndExponentCur = 0
+	r.bigInt128AndExponentCur = 0
 	return configuredIterations
 }
 
@@ -1780,6 +2299,50 @@ func (r *rando) BigUint128AndBitSizeAndBitValue() (*big.Int, uint, bool) {
 	return scheme.Values(r)
 }
 
+func (r *rando) BigInt128AndBitSize() (*big.Int, uint) {
+	r.ensureOnePerTest()
+
+	scheme := r.bigInt128AndBitSizeSchemes[r.bigInt128AndBitSizeCur]
+	r.bigInt128AndBitSizeCur++
+	if r.bigInt128AndBitSizeCur >= len(r.bigInt128AndBitSizeSchemes) {
+		r.bigInt128AndBitSizeCur = 0
+	}
+	return scheme.Values(r)
+}
+
+func (r *rando) BigInt128AndBitSizeAndBitValue() (*big.Int, uint, bool) {
+	r.ensureOnePerTest()
+
+	scheme := r.bigInt128AndBitSizeAndBitValueSchemes[r.bigInt128AndBitSizeAndBitValueCur]
+	r.bigInt128AndBitSizeAndBitValueCur++
+	if r.bigInt128AndBitSizeAndBitValueCur >= len(r.bigInt128AndBitSizeAndBitValueSchemes) {
+		r.bigInt128AndBitSizeAndBitValueCur = 0
+	}
+	return scheme.Values(r)
+}
+
+func (r *rando) BigUint128AndExponent() (*big.Int, uint) {
+	r.ensureOnePerTest()
+
+	scheme := r.bigUint128AndExponentSchemes[r.bigUint128AndExponentCur]
+	r.bigUint128AndExponentCur++
+	if r.bigUint128AndExponentCur >= len(r.bigUint128AndExponentSchemes) {
+		r.bigUint128AndExponentCur = 0
+	}
+	return scheme.Values(r)
+}
+
+func (r *rando) BigInt128AndExponent() (*big.Int, uint) {
+	r.ensureOnePerTest()
+
+	scheme := r.bigInt128AndExponentSchemes[r.bigInt128AndExponentCur]
+	r.bigInt128AndExponentCur++
+	if r.bigInt128AndExponentCur >= len(r.bigInt128AndExponentSchemes) {
+		r.bigInt128AndExponentCur = 0
+	}
+	return scheme.Values(r)
+}
+
 func (r *rando) BigInt128() *big.Int {
 	r.ensureOnePerTest()
 	scheme := r.bigInt128Schemes[r.bigInt128Cur]
@@ -3,6 +3,7 @@ package geometry
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -68,6 +69,28 @@ func TestInt128Abs(t *testing.T) {
 			
 			result := tc.a.Abs()
 			require.Equal(t,tc.b, result)
+			require.Equal(t, tc.b, AbsInt128(tc.a))
+		})
+	}
+}
+
+func TestInt128AbsOverflow(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b     Int128
+		overflow bool
+	}{
+		{i64(0), i64(0), false},
+		{i64(1), i64(1), false},
+		{i64(-1), i64(1), false},
+		{Int128{hi: maxUint64}, Int128{hi: 1}, false},
+		{MaxInt128, MaxInt128, false},
+		{MinInt128.Inc(), MaxInt128, false},
+		{MinInt128, MinInt128, true},
+	} {
+		t.Run(fmt.Sprintf("%d/|%s|=%s", idx, tc.a, tc.b), func(t *testing.T) {
+			result, overflow := tc.a.AbsOverflow()
+			require.Equal(t, tc.b, result)
+			require.Equal(t, tc.overflow, overflow)
 		})
 	}
 }
@@ -93,6 +116,26 @@ func TestInt128AbsUint128(t *testing.T) {
 	}
 }
 
+func TestInt128SignAbs(t *testing.T) {
+	for idx, tc := range []struct {
+		a    Int128
+		sign int
+		mag  Uint128
+	}{
+		{MinInt128, -1, minInt128AsAbsUint128},
+		{i64(-1), -1, u64(1)},
+		{i64(0), 0, u64(0)},
+		{i64(1), 1, u64(1)},
+		{MaxInt128, 1, maxInt128AsUint128},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.a), func(t *testing.T) {
+			sign, mag := tc.a.SignAbs()
+			require.Equal(t, tc.sign, sign)
+			require.Equal(t, tc.mag, mag)
+		})
+	}
+}
+
 func TestInt128Add(t *testing.T) {
 	for idx, tc := range []struct {
 		a, b, c Int128
@@ -163,6 +206,45 @@ func TestInt128AsBigIntAndIntoBigInt(t *testing.T) {
 	}
 }
 
+func TestInt128IntoBigIntReuse(t *testing.T) {
+	var b big.Int
+	for _, tc := range []Int128{MinInt128, i64(-1), MaxInt128, i64(1), i64(0), i64(-2)} {
+		tc.IntoBigInt(&b)
+		require.Equal(t, tc.String(), b.String())
+	}
+}
+
+func TestInt128IntoBigIntWords32(t *testing.T) {
+	// The 32-bit path can't be selected at runtime because intSize is a
+	// constant, so rebuild the value from the 32-bit word layout by hand:
+	for idx, tc := range []struct {
+		a Int128
+		b *big.Int
+	}{
+		{i64(0), bigI64(0)},
+		{i64(2), bigI64(2)},
+		{i64(-2), bigI64(-2)},
+		{Int128{0x1, 0xFFFFFFFFFFFFFFFF}, bigs("36893488147419103231")},
+		{MaxInt128, bigs("170141183460469231731687303715884105727")},
+		{MinInt128, bigs("-170141183460469231731687303715884105728")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.b), func(t *testing.T) {
+			words := putUint128Words(nil, tc.a.hi, tc.a.lo, 32)
+			require.Len(t, words, 4)
+
+			v := new(big.Int)
+			for w := len(words) - 1; w >= 0; w-- {
+				require.True(t, uint64(words[w]) <= 0xFFFFFFFF)
+				v.Lsh(v, 32).Or(v, new(big.Int).SetUint64(uint64(words[w])))
+			}
+			if tc.a.hi&int128SignBit != 0 {
+				v.Xor(v, maxBigUint128).Add(v, big1).Neg(v)
+			}
+			require.True(t, tc.b.Cmp(v) == 0, "found: %s", v)
+		})
+	}
+}
+
 func TestInt128AsFloat64Random(t *testing.T) {
 	
 
@@ -245,6 +327,342 @@ func TestInt128AsInt64(t *testing.T) {
 	}
 }
 
+func TestInt128Between(t *testing.T) {
+	for idx, tc := range []struct {
+		i, lo, hi Int128
+		out       bool
+	}{
+		{i64(0), i64(-1), i64(1), true},
+		{i64(-1), i64(-1), i64(1), true},
+		{i64(1), i64(-1), i64(1), true},
+		{i64(2), i64(-1), i64(1), false},
+		{i64(-2), i64(-1), i64(1), false},
+		{MinInt128, MinInt128, MaxInt128, true},
+		{MaxInt128, MinInt128, MaxInt128, true},
+		{i64(0), i64(1), i64(-1), false}, // inverted bounds
+		{i64(1), i64(1), i64(-1), false}, // inverted bounds
+	} {
+		t.Run(fmt.Sprintf("%d/%s<=%s<=%s", idx, tc.lo, tc.i, tc.hi), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.Between(tc.lo, tc.hi))
+		})
+	}
+}
+
+func TestInt128AsBigFloatExact(t *testing.T) {
+	bts := make([]byte, 16)
+	check := func(i Int128) {
+		f := i.AsBigFloatExact()
+		require.Equal(t, uint(128), f.Prec())
+		bi, acc := f.Int(nil)
+		require.Equal(t, big.Exact, acc)
+		require.True(t, i.AsBigInt().Cmp(bi) == 0, "%s != %s", i, bi)
+	}
+	for _, i := range []Int128{i64(0), i64(1), i64(-1), MinInt128, MaxInt128, MinInt128.Add(i64(1))} {
+		check(i)
+	}
+	for n := 0; n < 1000; n++ {
+		check(randInt128(bts))
+	}
+}
+
+func TestInt128FromStringSeparators(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		out Int128
+		ok  bool
+	}{
+		{"18_446_744_073_709_551_616", i128s("18446744073709551616"), true},
+		{"-18_446_744_073_709_551_616", i128s("-18446744073709551616"), true},
+		{"+1_000", i64(1000), true},
+		{"1000", i64(1000), true},
+		{"_1", zeroInt128, false},
+		{"-_1", zeroInt128, false},
+		{"1_", zeroInt128, false},
+		{"1__2", zeroInt128, false},
+		{"_", zeroInt128, false},
+	} {
+		out, _, err := Int128FromString(tc.in)
+		require.Equal(t, tc.ok, err == nil, "%q: %v", tc.in, err)
+		require.Equal(t, tc.out, out, "%q", tc.in)
+	}
+}
+
+func TestInt128Text(t *testing.T) {
+	require.Equal(t, "-80000000000000000000000000000000", MinInt128.Text(16))
+	require.Equal(t, "7fffffffffffffffffffffffffffffff", MaxInt128.Text(16))
+	require.Equal(t, "-170141183460469231731687303715884105728", MinInt128.Text(10))
+	require.Equal(t, "-1"+strings.Repeat("0", 127), MinInt128.Text(2))
+	require.Equal(t, "-ffffffffffffffff", i64(minInt64).Sub64(maxInt64).Text(16))
+	require.Equal(t, "-8000000000000000", i64(minInt64).Text(16))
+	require.Equal(t, "-z", i64(-35).Text(36))
+
+	bts := make([]byte, 16)
+	values := []Int128{
+		i64(0), i64(1), i64(-1), i64(minInt64), i64(maxInt64),
+		i64(minInt64).Dec(), i64(maxInt64).Inc(),
+		MinInt128, MinInt128.Inc(), MaxInt128,
+	}
+	for i := 0; i < 200; i++ {
+		values = append(values, randInt128(bts))
+	}
+
+	for base := 2; base <= 36; base++ {
+		for _, i := range values {
+			expected := i.AsBigInt().Text(base)
+			result := i.Text(base)
+			require.Equal(t, expected, result, "%s in base %d", i, base)
+			require.Equal(t, expected, FormatInt128(i, base))
+
+			back, accurate, err := Int128FromBase(result, base)
+			require.NoError(t, err)
+			require.True(t, accurate)
+			require.Equal(t, i, back)
+		}
+	}
+
+	for _, i := range values {
+		require.Equal(t, i.AsBigInt().String(), i.String())
+	}
+
+	require.Panics(t, func() { i64(1).Text(1) })
+	require.Panics(t, func() { i64(-1).Text(37) })
+}
+
+func TestInt128Endian(t *testing.T) {
+	for idx, tc := range []struct {
+		i  Int128
+		be string
+	}{
+		{i64(0), "00000000000000000000000000000000"},
+		{i64(1), "00000000000000000000000000000001"},
+		{i64(-1), "ffffffffffffffffffffffffffffffff"},
+		{i64(-2), "fffffffffffffffffffffffffffffffe"},
+		{MinInt128, "80000000000000000000000000000000"},
+		{MaxInt128, "7fffffffffffffffffffffffffffffff"},
+		{i64(minInt64), "ffffffffffffffff8000000000000000"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.i), func(t *testing.T) {
+			be := make([]byte, 16)
+			tc.i.PutBigEndian(be)
+			require.Equal(t, tc.be, hex.EncodeToString(be))
+			require.Equal(t, tc.i, MustInt128FromBigEndian(be))
+
+			le := make([]byte, 16)
+			tc.i.PutLittleEndian(le)
+			for n := range le {
+				require.Equal(t, be[15-n], le[n])
+			}
+			require.Equal(t, tc.i, MustInt128FromLittleEndian(le))
+		})
+	}
+
+	require.Panics(t, func() { i64(1).PutBigEndian(make([]byte, 15)) })
+	require.Panics(t, func() { MustInt128FromLittleEndian(make([]byte, 15)) })
+}
+
+func TestInt128FromBase(t *testing.T) {
+	for idx, tc := range []struct {
+		in   string
+		base int
+		out  Int128
+		ok   bool
+	}{
+		{"0xFF", 0, i64(255), true},
+		{"-0xFF", 0, i64(-255), true},
+		{"+0xff", 0, i64(255), true},
+		{"-0b101", 0, i64(-5), true},
+		{"-0o17", 0, i64(-15), true},
+		{"-017", 0, i64(-15), true},
+		{"-0x8000_0000_0000_0000_0000_0000_0000_0000", 0, MinInt128, true},
+		{"0x7fffffffffffffffffffffffffffffff", 0, MaxInt128, true},
+		{"-ff", 16, i64(-255), true},
+		{"-FF", 16, i64(-255), true},
+		{"017", 10, i64(17), true},
+		{"-z", 36, i64(-35), true},
+		{"-0xFF", 16, zeroInt128, false}, // Prefixes are only allowed with base 0
+		{"0xFF", 10, zeroInt128, false},
+		{"-", 0, zeroInt128, false},
+		{"1", 1, zeroInt128, false},
+		{"1", 63, zeroInt128, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s@%d", idx, tc.in, tc.base), func(t *testing.T) {
+			out, accurate, err := Int128FromBase(tc.in, tc.base)
+			require.Equal(t, tc.ok, err == nil, "%v", err)
+			require.Equal(t, tc.ok, accurate)
+			require.Equal(t, tc.out, out)
+		})
+	}
+
+	// Overflow truncates:
+	out, accurate, err := Int128FromBase("-0x8000_0000_0000_0000_0000_0000_0000_0001", 0)
+	require.NoError(t, err)
+	require.False(t, accurate)
+	require.Equal(t, MinInt128, out)
+
+	// JSON is always decimal:
+	var i Int128
+	require.NoError(t, json.Unmarshal([]byte(`"-010"`), &i))
+	require.Equal(t, i64(-10), i)
+}
+
+func TestInt128MulDiv(t *testing.T) {
+	check := func(i, mul, div Int128) {
+		v := new(big.Int).Mul(i.AsBigInt(), mul.AsBigInt())
+		v.Quo(v, div.AsBigInt())
+		v = simulateBigInt128Overflow(v)
+		require.Equal(t, v.String(), i.MulDiv(mul, div).String(), "%s*%s/%s", i, mul, div)
+	}
+
+	for idx, tc := range []struct {
+		i, mul, div, out Int128
+	}{
+		{i64(6), i64(7), i64(3), i64(14)},
+		{i64(-6), i64(7), i64(3), i64(-14)},
+		{i64(6), i64(-7), i64(-3), i64(14)},
+		{i64(-6), i64(-7), i64(-3), i64(-14)},
+		{i64(-7), i64(1), i64(2), i64(-3)}, // Truncated, not floored
+		{i64(7), i64(-1), i64(2), i64(-3)},
+		{MaxInt128, MaxInt128, MaxInt128, MaxInt128},
+		{MinInt128, MinInt128, MinInt128, MinInt128},
+		{MinInt128, MaxInt128, MinInt128, MaxInt128},
+		{MinInt128, MinInt128, MaxInt128.Neg(), MaxInt128}, // -(2^127+1) wraps
+		{MaxInt128, i64(-3), i64(4), i128s("-127605887595351923798765477786913079295")},
+		{MinInt128, i64(1), i64(-1), MinInt128}, // Quotient overflows and wraps, like Mul
+		{i64(0), MinInt128, i64(-7), i64(0)},
+	} {
+		t.Run(fmt.Sprintf("%d/%s*%s/%s", idx, tc.i, tc.mul, tc.div), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.MulDiv(tc.mul, tc.div))
+			check(tc.i, tc.mul, tc.div)
+		})
+	}
+
+	bts := make([]byte, 16)
+	for n := 0; n < 1000; n++ {
+		i, mul, div := randInt128(bts), randInt128(bts), randInt128(bts)
+		if div.IsZero() {
+			continue
+		}
+		check(i, mul, div)
+	}
+
+	require.Panics(t, func() { i64(1).MulDiv(i64(1), i64(0)) })
+}
+
+func TestInt128MulOverflow(t *testing.T) {
+	min, max := MinInt128, MaxInt128
+	half := i64(1).Lsh(64) // 2^64; half*half is 2^128
+
+	for idx, tc := range []struct {
+		a, b     Int128
+		overflow bool
+	}{
+		{i64(0), min, false},
+		{i64(1), min, false},
+		{i64(-1), max, false},
+		{i64(-1), min, true},
+		{min, i64(-1), true},
+		{min, min, true},
+		{max, max, true},
+		{max, i64(2), true},
+		{max, i64(-2), true},
+		{min.Rsh(1), i64(2), false}, // -2^126 * 2 == MinInt128
+		{min.Rsh(1), i64(-2), true}, // -2^126 * -2 == MaxInt128 + 1
+		{max.Rsh(1), i64(2), false},
+		{max.Rsh(1).Inc(), i64(2), true},
+		{half, half, true},
+		{half, half.Neg(), true},
+		{half.Rsh(1), half, true},        // 2^127
+		{half.Rsh(1), half.Neg(), false}, // -2^127
+		{half.Rsh(1).Neg(), half.Neg(), true},
+	} {
+		for _, pair := range [2][2]Int128{{tc.a, tc.b}, {tc.b, tc.a}} {
+			a, b := pair[0], pair[1]
+			t.Run(fmt.Sprintf("%d/%s*%s", idx, a, b), func(t *testing.T) {
+				v, overflow := a.MulOverflow(b)
+				require.Equal(t, a.Mul(b), v)
+				require.Equal(t, tc.overflow, overflow)
+
+				rb := new(big.Int).Mul(a.AsBigInt(), b.AsBigInt())
+				require.Equal(t, rb.Cmp(maxBigInt128) > 0 || rb.Cmp(minBigInt128) < 0, overflow)
+			})
+		}
+	}
+}
+
+func TestInt128Saturating(t *testing.T) {
+	zero, one, neg1 := i64(0), i64(1), i64(-1)
+	min, max := MinInt128, MaxInt128
+
+	for _, tc := range []struct {
+		a, b          Int128
+		add, sub, mul Int128
+	}{
+		{zero, zero, zero, zero, zero},
+		{one, neg1, zero, i64(2), neg1},
+		{max, one, max, max.Dec(), max},
+		{max, neg1, max.Dec(), max, min.Inc()},
+		{min, one, min.Inc(), min, min},
+		{min, neg1, min, min.Inc(), max}, // -MinInt128 isn't representable
+		{min, min, min, zero, max},
+		{max, max, max, zero, max},
+		{min, max, neg1, min, min},
+		{max, min, neg1, max, min},
+		{neg1, min, min, max, max},
+		{zero, min, min, max, zero},
+
+		// 2 * -2^126 is exactly MinInt128, but -2 * -2^126 is one too big:
+		{i64(2), min.Rsh(1), min.Rsh(1).Add64(2), max.Rsh(1).Add64(3), min},
+		{i64(-2), min.Rsh(1), min.Rsh(1).Sub64(2), max.Rsh(1).Dec(), max},
+	} {
+		require.Equal(t, tc.add, tc.a.SaturatingAdd(tc.b), "%s + %s", tc.a, tc.b)
+		require.Equal(t, tc.sub, tc.a.SaturatingSub(tc.b), "%s - %s", tc.a, tc.b)
+		require.Equal(t, tc.mul, tc.a.SaturatingMul(tc.b), "%s * %s", tc.a, tc.b)
+	}
+
+	clamp := func(b *big.Int) Int128 {
+		if b.Cmp(maxBigInt128) > 0 {
+			return MaxInt128
+		} else if b.Cmp(minBigInt128) < 0 {
+			return MinInt128
+		}
+		return accInt128FromBigInt(b)
+	}
+
+	bts := make([]byte, 16)
+	for n := 0; n < 1000; n++ {
+		a, b := randInt128(bts), randInt128(bts)
+		if n%2 == 0 {
+			b = b.Rsh(uint(n % 128)) // Keep some products in range
+		}
+		ba, bb := a.AsBigInt(), b.AsBigInt()
+		require.Equal(t, clamp(new(big.Int).Add(ba, bb)), a.SaturatingAdd(b), "%s + %s", a, b)
+		require.Equal(t, clamp(new(big.Int).Sub(ba, bb)), a.SaturatingSub(b), "%s - %s", a, b)
+		require.Equal(t, clamp(new(big.Int).Mul(ba, bb)), a.SaturatingMul(b), "%s * %s", a, b)
+	}
+}
+
+func TestInt128Clamp(t *testing.T) {
+	for idx, tc := range []struct {
+		i, lo, hi Int128
+		out       Int128
+	}{
+		{i64(0), i64(-1), i64(1), i64(0)},
+		{i64(-2), i64(-1), i64(1), i64(-1)},
+		{i64(2), i64(-1), i64(1), i64(1)},
+		{i64(-1), i64(-1), i64(-1), i64(-1)},
+		{MinInt128, i64(-10), i64(10), i64(-10)},
+		{MaxInt128, i64(-10), i64(10), i64(10)},
+		{i64(-1), i64(0), MaxInt128, i64(0)}, // would be MaxUint128 unsigned
+		{MinInt128, MinInt128, MaxInt128, MinInt128},
+	} {
+		t.Run(fmt.Sprintf("%d/clamp(%s,%s,%s)", idx, tc.i, tc.lo, tc.hi), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.Clamp(tc.lo, tc.hi))
+		})
+	}
+
+	require.Panics(t, func() { i64(0).Clamp(i64(1), i64(-1)) })
+}
+
 func TestInt128Cmp(t *testing.T) {
 	for idx, tc := range []struct {
 		a, b   Int128
@@ -302,6 +720,26 @@ func TestInt128Format(t *testing.T) {
 	}
 }
 
+func TestInt128FormatStruct(t *testing.T) {
+	type wrapper struct{ I Int128 }
+
+	for idx, tc := range []struct {
+		in  wrapper
+		f   string
+		out string
+	}{
+		{wrapper{MaxInt128}, "%v", "{170141183460469231731687303715884105727}"},
+		{wrapper{MaxInt128}, "%+v", "{I:170141183460469231731687303715884105727}"},
+		{wrapper{MinInt128}, "%+v", "{I:-170141183460469231731687303715884105728}"},
+		{wrapper{MaxInt128}, "%x", "{7fffffffffffffffffffffffffffffff}"},
+		{wrapper{i64(-255)}, "%X", "{-FF}"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.f), func(t *testing.T) {
+			require.Equal(t, tc.out, fmt.Sprintf(tc.f, tc.in))
+		})
+	}
+}
+
 func TestInt128From64(t *testing.T) {
 	for idx, tc := range []struct {
 		in  Int64
@@ -370,6 +808,32 @@ func TestInt128FromFloat64(t *testing.T) {
 	}
 }
 
+func TestInt128FromFloat64Exact(t *testing.T) {
+	for idx, tc := range []struct {
+		f       float64
+		out     Int128
+		inRange bool
+	}{
+		{-1.5, i64(-1), true}, // truncates towards zero
+		{-1, i64(-1), true},
+		{math.Ldexp(1, 64), Int128{hi: 1}, true},
+		{-math.Ldexp(1, 64), Int128{hi: maxUint64}, true},
+		{-math.Ldexp(3, 64), Int128{hi: maxUint64 - 2}, true},
+		{math.Ldexp(1, 126), Int128{hi: 1 << 62}, true},
+		{math.Ldexp(1, 127), MaxInt128, false},
+		{-math.Ldexp(1, 127), MinInt128, true},
+	} {
+		t.Run(fmt.Sprintf("%d/fromfloat64(%g)==%s", idx, tc.f, tc.out), func(t *testing.T) {
+			rn, inRange := Int128FromFloat64(tc.f)
+			require.Equal(t, tc.inRange, inRange)
+			require.Equal(t, tc.out, rn)
+			if inRange && tc.f == math.Trunc(tc.f) {
+				require.Equal(t, tc.f, rn.AsFloat64())
+			}
+		})
+	}
+}
+
 func TestInt128FromFloat64Random(t *testing.T) {
 	
 
@@ -404,6 +868,57 @@ func TestInt128FromSize(t *testing.T) {
 	require.Equal(t, Int128FromInt32(-2147483648), i128s("-2147483648"))
 }
 
+func TestInt128QFloat(t *testing.T) {
+	for idx, tc := range []struct {
+		f        float64
+		fracBits int
+		out      Int128
+		inRange  bool
+	}{
+		{0, 16, i64(0), true},
+		{1, 0, i64(1), true},
+		{1.5, 0, i64(2), true}, // halves round away from zero
+		{-1.5, 0, i64(-2), true},
+		{1, 8, i64(256), true},
+		{-1, 8, i64(-256), true},
+		{0.5, 1, i64(1), true},
+		{1, 64, Int128{hi: 1}, true},
+		{-1, 64, Int128{hi: maxUint64}, true},
+		{1, 126, Int128{hi: 1 << 62}, true},
+		{1, 127, MaxInt128, false},
+		{-1, 127, MinInt128, true},
+		{1e30, 64, MaxInt128, false},
+		{-1e30, 64, MinInt128, false},
+		{math.NaN(), 8, i64(0), false},
+	} {
+		t.Run(fmt.Sprintf("%d/%g,Q%d", idx, tc.f, tc.fracBits), func(t *testing.T) {
+			out, inRange := Int128FromQFloat(tc.f, tc.fracBits)
+			require.Equal(t, tc.inRange, inRange)
+			require.Equal(t, tc.out, out)
+			if scaled := math.Ldexp(tc.f, tc.fracBits); inRange && scaled == math.Round(scaled) {
+				require.Equal(t, tc.f, out.ToQFloat(tc.fracBits))
+			}
+		})
+	}
+}
+
+func TestInt128QFloatRoundTrip(t *testing.T) {
+	for _, fracBits := range []int{0, 1, 8, 16, 32, 48, 64, 96} {
+		for _, f := range []float64{0, 1, -1, math.Pi, -math.E, 1234.5678, -0.001, 1e-9, 12345678.9} {
+			t.Run(fmt.Sprintf("%g,Q%d", f, fracBits), func(t *testing.T) {
+				q, inRange := Int128FromQFloat(f, fracBits)
+				require.True(t, inRange)
+
+				// Rounding to the nearest step can lose at most half a step, on
+				// top of float64's own relative error:
+				step := math.Ldexp(1, -fracBits)
+				diff := math.Abs(q.ToQFloat(fracBits) - f)
+				require.True(t, diff <= step/2+math.Abs(f)*1e-15, "diff %g > %g", diff, step/2)
+			})
+		}
+	}
+}
+
 func TestInt128Inc(t *testing.T) {
 	for idx, tc := range []struct {
 		a, b Int128
@@ -484,6 +999,62 @@ func TestInt128MarshalText(t *testing.T) {
 	}
 }
 
+func TestInt128MarshalTextBase(t *testing.T) {
+	for idx, tc := range []struct {
+		a    Int128
+		base int
+		out  string
+	}{
+		{i64(0), 16, "0x0"},
+		{i64(255), 16, "0xff"},
+		{i64(-255), 16, "-0xff"},
+		{i64(-5), 2, "-0b101"},
+		{i64(8), 8, "0o10"},
+		{MinInt128, 16, "-0x80000000000000000000000000000000"},
+		{MaxInt128, 10, "170141183460469231731687303715884105727"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			out, err := tc.a.MarshalTextBase(tc.base)
+			require.NoError(t, err)
+			require.Equal(t, tc.out, string(out))
+
+			var v Int128
+			require.NoError(t, v.UnmarshalText(out))
+			require.Equal(t, tc.a, v)
+		})
+	}
+
+	_, err := i64(1).MarshalTextBase(36)
+	require.Error(t, err)
+
+	// Out of range, so v must be left alone:
+	v := i64(7)
+	require.Error(t, v.UnmarshalText([]byte("0x80000000000000000000000000000000")))
+	require.Error(t, v.UnmarshalText([]byte("-0x80000000000000000000000000000001")))
+	require.Error(t, v.UnmarshalText([]byte("170141183460469231731687303715884105728")))
+	require.Equal(t, i64(7), v)
+}
+
+func TestInt128MarshalTextDefaultBase(t *testing.T) {
+	defer func(base int) { TextMarshalBase = base }(TextMarshalBase)
+
+	out, err := i64(-255).MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "-255", string(out))
+
+	TextMarshalBase = 16
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		n := randInt128(bts)
+		out, err := n.MarshalText()
+		require.NoError(t, err)
+
+		var v Int128
+		require.NoError(t, v.UnmarshalText(out))
+		require.Equal(t, n, v)
+	}
+}
+
 func TestInt128Mul(t *testing.T) {
 	for _, tc := range []struct {
 		a, b, out Int128
@@ -614,6 +1185,118 @@ func TestInt128QuoRem(t *testing.T) {
 	}
 }
 
+func TestInt128DivModVsQuoRem(t *testing.T) {
+	// QuoRem truncates towards zero, so the remainder takes the sign of the
+	// dividend; DivMod is Euclidean, so the modulus is never negative:
+	for idx, tc := range []struct {
+		i, by    Int128
+		quo, rem Int128
+		div, mod Int128
+	}{
+		{i64(7), i64(3), i64(2), i64(1), i64(2), i64(1)},
+		{i64(-7), i64(3), i64(-2), i64(-1), i64(-3), i64(2)},
+		{i64(7), i64(-3), i64(-2), i64(1), i64(-2), i64(1)},
+		{i64(-7), i64(-3), i64(2), i64(-1), i64(3), i64(2)},
+		{i64(-6), i64(3), i64(-2), i64(0), i64(-2), i64(0)},
+		{i64(-1), MaxInt128, i64(0), i64(-1), i64(-1), MaxInt128.Dec()},
+		{i64(-1), MinInt128, i64(0), i64(-1), i64(1), MaxInt128},
+		{MinInt128, i64(3), i128s("-56713727820156410577229101238628035242"), i64(-2), i128s("-56713727820156410577229101238628035243"), i64(1)},
+		{MinInt128, i64(1), MinInt128, i64(0), MinInt128, i64(0)},
+		{MinInt128, MinInt128, i64(1), i64(0), i64(1), i64(0)},
+		{MinInt128, MaxInt128, i64(-1), i64(-1), i64(-2), MaxInt128.Dec()},
+		{MaxInt128, MinInt128, i64(0), MaxInt128, i64(0), MaxInt128},
+		{MaxInt128, i64(-1), MinInt128.Inc(), i64(0), MinInt128.Inc(), i64(0)},
+		{MinInt128, i64(-1), MinInt128, i64(0), MinInt128, i64(0)}, // Overflow
+	} {
+		t.Run(fmt.Sprintf("%d/%s÷%s", idx, tc.i, tc.by), func(t *testing.T) {
+			quo, rem := tc.i.QuoRem(tc.by)
+			require.Equal(t, tc.quo, quo, "quo")
+			require.Equal(t, tc.rem, rem, "rem")
+
+			div, mod := tc.i.DivMod(tc.by)
+			require.Equal(t, tc.div, div, "div")
+			require.Equal(t, tc.mod, mod, "mod")
+
+			if tc.i == MinInt128 && tc.by == i64(-1) {
+				return // big.Int doesn't overflow
+			}
+			bq, br := new(big.Int).QuoRem(tc.i.AsBigInt(), tc.by.AsBigInt(), new(big.Int))
+			require.Equal(t, bq.String(), quo.String())
+			require.Equal(t, br.String(), rem.String())
+
+			bd, bm := new(big.Int).DivMod(tc.i.AsBigInt(), tc.by.AsBigInt(), new(big.Int))
+			require.Equal(t, bd.String(), div.String())
+			require.Equal(t, bm.String(), mod.String())
+		})
+	}
+
+	bts := make([]byte, 16)
+	for n := 0; n < 1000; n++ {
+		i, by := randInt128(bts), randInt128(bts)
+		if by.IsZero() || (i == MinInt128 && by == i64(-1)) {
+			continue
+		}
+		div, mod := i.DivMod(by)
+		bd, bm := new(big.Int).DivMod(i.AsBigInt(), by.AsBigInt(), new(big.Int))
+		require.Equal(t, bd.String(), div.String(), "%s div %s", i, by)
+		require.Equal(t, bm.String(), mod.String(), "%s mod %s", i, by)
+	}
+}
+
+func TestInt128QuoRem64Overflow(t *testing.T) {
+	q, r := MinInt128.QuoRem64(-1)
+	require.Equal(t, MinInt128, q)
+	require.Equal(t, i64(0), r)
+	require.Equal(t, MinInt128, MinInt128.Quo64(-1))
+	require.Equal(t, i64(0), MinInt128.Rem64(-1))
+
+	// These must agree with the full-width versions:
+	fq, fr := MinInt128.QuoRem(i64(-1))
+	require.Equal(t, fq, q)
+	require.Equal(t, fr, r)
+
+	// Neighbouring cases that don't overflow:
+	for _, tc := range []struct {
+		i  Int128
+		by int64
+	}{
+		{MinInt128, 1},
+		{MinInt128, 2},
+		{MinInt128, -2},
+		{MinInt128, minInt64},
+		{MinInt128, maxInt64},
+		{MinInt128.Inc(), -1},
+		{MaxInt128, -1},
+		{MaxInt128, minInt64},
+	} {
+		bq, br := new(big.Int).QuoRem(tc.i.AsBigInt(), big.NewInt(tc.by), new(big.Int))
+		q, r := tc.i.QuoRem64(tc.by)
+		require.Equal(t, bq.String(), q.String(), "%s / %d", tc.i, tc.by)
+		require.Equal(t, br.String(), r.String(), "%s %% %d", tc.i, tc.by)
+		require.Equal(t, q, tc.i.Quo64(tc.by))
+		require.Equal(t, r, tc.i.Rem64(tc.by))
+	}
+}
+
+func TestInt128ScientificString(t *testing.T) {
+	for idx, tc := range []struct {
+		i   Int128
+		sig int
+		out string
+	}{
+		{i64(0), 3, "0.00e+00"},
+		{i64(-5), 2, "-5.0e+00"},
+		{i64(-12355), 3, "-1.24e+04"},
+		{MaxInt128, 3, "1.70e+38"},
+		{MinInt128, 3, "-1.70e+38"},
+		{MinInt128, 39, "-1.70141183460469231731687303715884105728e+38"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.i), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.ScientificString(tc.sig))
+		})
+	}
+}
+
 func TestInt128Scan(t *testing.T) {
 	for idx, tc := range []struct {
 		in  string
@@ -621,7 +1304,8 @@ func TestInt128Scan(t *testing.T) {
 		ok  bool
 	}{
 		{"1", i64(1), true},
-		{"0xFF", zeroInt128, false},
+		{"0xFF", i64(0xFF), true},
+		{"-0xFF", i64(-0xFF), true},
 		{"-1", i64(-1), true},
 		{"170141183460469231731687303715884105728", zeroInt128, false},
 		{"-170141183460469231731687303715884105729", zeroInt128, false},
@@ -641,6 +1325,258 @@ func TestInt128Scan(t *testing.T) {
 	}
 }
 
+func TestInt128ShlWrap(t *testing.T) {
+	// The reference shifts the 128-bit two's complement pattern, masks it back
+	// to 128 bits and reinterprets bit 127 as the sign:
+	ref := func(i Int128, n uint) *big.Int {
+		v := new(big.Int).Lsh(i.AsUint128().AsBigInt(), n)
+		v.And(v, maxBigUint128)
+		if v.Bit(127) == 1 {
+			v.Sub(v, wrapBigUint128)
+		}
+		return v
+	}
+
+	for idx, tc := range []struct {
+		i   Int128
+		n   uint
+		out Int128
+	}{
+		{i64(1), 0, i64(1)},
+		{i64(1), 126, i128s("0x40000000000000000000000000000000")},
+		{i64(1), 127, MinInt128}, // Shifted into the sign bit
+		{i64(1), 128, i64(0)},
+		{i64(-1), 1, i64(-2)},
+		{i64(-1), 127, MinInt128},
+		{i64(-1), 128, i64(0)},
+		{i64(-3), 126, i128s("0x40000000000000000000000000000000")}, // Negative wraps to positive
+		{MinInt128, 1, i64(0)},
+		{MaxInt128, 1, i64(-2)},
+		{i64(minInt64), 64, i128s("-170141183460469231731687303715884105728")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s<<%d", idx, tc.i, tc.n), func(t *testing.T) {
+			out := tc.i.ShlWrap(tc.n)
+			require.Equal(t, tc.out, out)
+			require.Equal(t, ref(tc.i, tc.n).String(), out.String())
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randInt128(bts)
+		for _, n := range []uint{0, 1, 63, 64, 65, 127, 128, 200} {
+			require.Equal(t, ref(v, n).String(), v.ShlWrap(n).String(), "%s<<%d", v, n)
+		}
+	}
+}
+
+func TestInt128Lsh(t *testing.T) {
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randInt128(bts)
+		for _, n := range []uint{0, 1, 63, 64, 65, 127, 128, 200} {
+			require.Equal(t, v.ShlWrap(n), v.Lsh(n), "%s<<%d", v, n)
+		}
+	}
+}
+
+func TestInt128Rsh(t *testing.T) {
+	for idx, tc := range []struct {
+		i   Int128
+		n   uint
+		out Int128
+	}{
+		{i64(-4), 1, i64(-2)},
+		{i64(4), 1, i64(2)},
+		{i64(-1), 0, i64(-1)},
+		{i64(-1), 1, i64(-1)},
+		{i64(-1), 128, i64(-1)},
+		{i64(-3), 1, i64(-2)}, // Rounds towards negative infinity, not zero
+		{MinInt128, 63, i128s("-0x10000000000000000")},
+		{MinInt128, 64, i64(minInt64)},
+		{MinInt128, 65, i64(minInt64 / 2)},
+		{MinInt128, 127, i64(-1)},
+		{MinInt128, 128, i64(-1)},
+		{MaxInt128, 63, i128s("0xffffffffffffffff")},
+		{MaxInt128, 64, i64(maxInt64)},
+		{MaxInt128, 65, i64(maxInt64 / 2)},
+		{MaxInt128, 127, i64(0)},
+		{MaxInt128, 128, i64(0)},
+		{i128s("0x12345678123456781234567812345678"), 0, i128s("0x12345678123456781234567812345678")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s>>%d", idx, tc.i, tc.n), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.Rsh(tc.n))
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randInt128(bts)
+		for _, n := range []uint{0, 1, 63, 64, 65, 127, 128, 200} {
+			expected := new(big.Int).Rsh(v.AsBigInt(), n)
+			require.Equal(t, expected.String(), v.Rsh(n).String(), "%s>>%d", v, n)
+		}
+	}
+}
+
+func TestInt128Bit(t *testing.T) {
+	require.Equal(t, uint(1), i64(-1).Bit(127))
+	require.Equal(t, uint(1), MinInt128.Bit(127))
+	require.Equal(t, uint(0), MinInt128.Bit(126))
+	require.Equal(t, uint(0), MaxInt128.Bit(127))
+	require.Equal(t, uint(1), MaxInt128.Bit(0))
+	require.Equal(t, uint(0), i64(-2).Bit(0))
+	require.Equal(t, uint(1), i64(-2).Bit(64)) // Sign extended into hi
+
+	require.Equal(t, MinInt128, i64(0).SetBit(127, 1))
+	require.Equal(t, MaxInt128, i64(-1).SetBit(127, 0))
+	require.Equal(t, i64(-1), i64(-2).SetBit(0, 1))
+	require.Equal(t, i128s("0x10000000000000000"), i64(0).SetBit(64, 1))
+
+	require.Panics(t, func() { i64(0).Bit(-1) })
+	require.Panics(t, func() { i64(0).Bit(128) })
+	require.Panics(t, func() { i64(0).SetBit(128, 1) })
+	require.Panics(t, func() { i64(0).SetBit(0, 2) })
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randInt128(bts)
+		for _, n := range []int{0, 1, 63, 64, 65, 126, 127} {
+			require.Equal(t, v.AsBigInt().Bit(n), v.Bit(n), "%s bit %d", v, n)
+			require.Equal(t, v, v.SetBit(n, 1-v.Bit(n)).SetBit(n, v.Bit(n)))
+		}
+	}
+}
+
+func TestInt128BitCounts(t *testing.T) {
+	for idx, tc := range []struct {
+		i        Int128
+		ones     int
+		leading  uint
+		trailing uint
+	}{
+		{i64(0), 0, 128, 128},
+		{i64(1), 1, 127, 0},
+		{i64(-1), 128, 0, 0},
+		{i64(-2), 127, 0, 1},
+		{i64(-4), 126, 0, 2},
+		{MinInt128, 1, 0, 127},
+		{MaxInt128, 127, 1, 0},
+		{i64(minInt64), 65, 0, 63},
+		{i128s("0x10000000000000000"), 1, 63, 64},
+		{i128s("-0x10000000000000000"), 64, 0, 64},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.i), func(t *testing.T) {
+			require.Equal(t, tc.ones, tc.i.OnesCount())
+			require.Equal(t, tc.leading, tc.i.LeadingZeros())
+			require.Equal(t, tc.trailing, tc.i.TrailingZeros())
+		})
+	}
+}
+
+func TestInt128RotateLeft(t *testing.T) {
+	for _, tc := range []struct {
+		i  Int128
+		by int
+		r  Int128
+	}{
+		{i: i64(1), by: 1, r: i64(2)},
+		{i: i64(1), by: 2, r: i64(4)},
+		{i: u128s("0x0000_0000_0000_0000_8000_0000_0000_0000").AsInt128(), by: 1, r: u128s("0x0000_0000_0000_0001_0000_0000_0000_0000").AsInt128()},
+		{i: u128s("0x8000_0000_0000_0000_0000_0000_0000_0000").AsInt128(), by: 1, r: i64(1)},
+		{i: u128s("0xF000_0000_0000_0000_0000_0000_0000_0000").AsInt128(), by: 4, r: i64(0xF)},
+		{i: MinInt128, by: 64, r: u128s("0x8000_0000_0000_0000").AsInt128()},
+		{i: MinInt128, by: 127, r: u128s("0x4000_0000_0000_0000_0000_0000_0000_0000").AsInt128()},
+		{i: MinInt128, by: -1, r: u128s("0x4000_0000_0000_0000_0000_0000_0000_0000").AsInt128()},
+
+		// The sign bit rotates through like any other bit:
+		{i: i64(-1), by: 1, r: i64(-1)},
+		{i: i64(-1), by: 77, r: i64(-1)},
+		{i: i64(-2), by: 1, r: i64(-3)},
+		{i: i64(-2), by: -1, r: MaxInt128},
+		{i: MaxInt128, by: 1, r: i64(-2)},
+		{i: i64(1), by: -1, r: MinInt128},
+	} {
+		t.Run(fmt.Sprintf("%s rotl %d=%s", tc.i, tc.by, tc.r), func(t *testing.T) {
+			ri := tc.i.RotateLeft(tc.by)
+			require.Equal(t, tc.r.String(), ri.String(), "%s != %s", tc.r, ri)
+			require.Equal(t, tc.i, ri.RotateLeft(-tc.by))
+		})
+	}
+}
+
+func TestInt128Sqrt(t *testing.T) {
+	for idx, tc := range []struct {
+		i Int128
+		r Int128
+	}{
+		{i64(0), i64(0)},
+		{i64(1), i64(1)},
+		{i64(3), i64(1)},
+		{i64(4), i64(2)},
+		{i64(maxInt64), i64(3037000499)},
+		{MaxInt128, u64(13043817825332782212).AsInt128()},
+	} {
+		t.Run(fmt.Sprintf("%d/sqrt(%s)=%s", idx, tc.i, tc.r), func(t *testing.T) {
+			require.Equal(t, tc.r, tc.i.Sqrt())
+		})
+	}
+
+	require.Panics(t, func() { i64(-1).Sqrt() })
+	require.Panics(t, func() { MinInt128.Sqrt() })
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randInt128(bts).Abs()
+		if v.Sign() < 0 {
+			continue // MinInt128
+		}
+		expected := new(big.Int).Sqrt(v.AsBigInt())
+		require.Equal(t, expected.String(), v.Sqrt().String(), "sqrt(%s)", v)
+	}
+}
+
+func TestInt128Pow(t *testing.T) {
+	for idx, tc := range []struct {
+		i   Int128
+		exp uint
+		out Int128
+	}{
+		{i64(0), 0, i64(1)},
+		{i64(0), 1, i64(0)},
+		{i64(-5), 0, i64(1)},
+		{i64(2), 10, i64(1024)},
+		{i64(-2), 3, i64(-8)},
+		{i64(-2), 4, i64(16)},
+		{i64(-1), 127, i64(-1)},
+		{i64(-1), 128, i64(1)},
+		{i64(-2), 127, MinInt128},
+		{i64(2), 127, MinInt128}, // Overflow wraps
+		{i64(2), 128, i64(0)},
+		{i64(10), 38, i128s("100000000000000000000000000000000000000")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s**%d=%s", idx, tc.i, tc.exp, tc.out), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.i.Pow(tc.exp))
+		})
+	}
+}
+
+func TestSmallestLargestInt128(t *testing.T) {
+	require.Equal(t, i64(-5), SmallestInt128(i64(-5)))
+	require.Equal(t, i64(-5), LargestInt128(i64(-5)))
+
+	vs := []Int128{i64(3), MaxInt128, i64(-1), MinInt128, i64(0), i64(-1)}
+	require.Equal(t, MinInt128, SmallestInt128(vs...))
+	require.Equal(t, MaxInt128, LargestInt128(vs...))
+
+	// -1 has every bit set, so an unsigned comparison would get this wrong:
+	require.Equal(t, i64(-1), SmallestInt128(i64(1), i64(-1)))
+	require.Equal(t, i64(1), LargestInt128(i64(-1), i64(1)))
+
+	require.Panics(t, func() { SmallestInt128() })
+	require.Panics(t, func() { LargestInt128() })
+}
+
 func TestInt128Sign(t *testing.T) {
 	for idx, tc := range []struct {
 		a    Int128
@@ -656,6 +1592,7 @@ func TestInt128Sign(t *testing.T) {
 			
 			result := tc.a.Sign()
 			require.Equal(t,tc.sign, result)
+			require.Equal(t, tc.sign, SignInt128(tc.a))
 		})
 	}
 }
@@ -952,3 +1889,98 @@ func accInt128FromBigInt(b *big.Int) Int128 {
 	}
 	return i
 }
+
+// int128SmallValues are the operands for the exhaustive bitwise tests. The
+// values either side of zero are where sign-extension mistakes show up; the
+// extremes catch mistakes with the sign bit itself.
+var int128SmallValues = []Int128{
+	i64(-2), i64(-1), i64(0), i64(1), i64(2),
+	MinInt128, MaxInt128,
+}
+
+func TestInt128BitwiseExhaustive(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		i    func(a, b Int128) Int128
+		big  func(z, a, b *big.Int) *big.Int
+	}{
+		{"and", Int128.And, (*big.Int).And},
+		{"or", Int128.Or, (*big.Int).Or},
+		{"xor", Int128.Xor, (*big.Int).Xor},
+		{"andnot", Int128.AndNot, (*big.Int).AndNot},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, a := range int128SmallValues {
+				for _, b := range int128SmallValues {
+					// big.Int's bitwise ops behave as if negative values were in
+					// infinitely sign-extended two's complement, so for operands
+					// in Int128 range the result is always in range too:
+					expected := tc.big(new(big.Int), a.AsBigInt(), b.AsBigInt())
+					result := tc.i(a, b)
+					require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+						"%s %s %s: expected %s, found %s", a, tc.name, b, expected, result)
+				}
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		i    func(a Int128, b int64) Int128
+		big  func(z, a, b *big.Int) *big.Int
+	}{
+		{"and64", Int128.And64, (*big.Int).And},
+		{"or64", Int128.Or64, (*big.Int).Or},
+		{"xor64", Int128.Xor64, (*big.Int).Xor},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, a := range int128SmallValues {
+				for _, b := range []int64{-2, -1, 0, 1, 2, math.MinInt64, math.MaxInt64} {
+					// The int64 operand is sign-extended, so these must agree with
+					// the full-width op on i64(b):
+					expected := tc.big(new(big.Int), a.AsBigInt(), big.NewInt(b))
+					result := tc.i(a, b)
+					require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+						"%s %s %d: expected %s, found %s", a, tc.name, b, expected, result)
+				}
+			}
+		})
+	}
+
+	t.Run("not", func(t *testing.T) {
+		for _, a := range int128SmallValues {
+			expected := new(big.Int).Not(a.AsBigInt())
+			result := a.Not()
+			require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+				"^%s: expected %s, found %s", a, expected, result)
+			require.Equal(t, a, result.Not())
+
+			// ^a == -a-1 for every Int128, including MinInt128 and MaxInt128:
+			require.Equal(t, a.Neg().Sub(i64(1)), result)
+		}
+	})
+
+	t.Run("sign", func(t *testing.T) {
+		for _, a := range int128SmallValues {
+			for _, b := range int128SmallValues {
+				// The sign bit follows the same rules as any other bit:
+				neg := func(i Int128) bool { return i.Sign() < 0 }
+				require.Equal(t, neg(a) && neg(b), neg(a.And(b)), "%s & %s", a, b)
+				require.Equal(t, neg(a) || neg(b), neg(a.Or(b)), "%s | %s", a, b)
+				require.Equal(t, neg(a) != neg(b), neg(a.Xor(b)), "%s ^ %s", a, b)
+				require.Equal(t, neg(a) && !neg(b), neg(a.AndNot(b)), "%s &^ %s", a, b)
+			}
+		}
+	})
+
+	t.Run("identities", func(t *testing.T) {
+		for _, a := range int128SmallValues {
+			for _, b := range int128SmallValues {
+				require.Equal(t, a, a.Xor(b).Xor(b))
+				require.Equal(t, a.And(b.Not()), a.AndNot(b))
+				require.Equal(t, a.And(b).Not(), a.Not().Or(b.Not()))
+				require.Equal(t, a.Or(b).Not(), a.Not().And(b.Not()))
+			}
+		}
+	})
+}
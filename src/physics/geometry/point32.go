@@ -15,9 +15,9 @@ func (p Point32) IsZero() bool {
 
 func (p *Point32) Cross32(b Point32) Point64 {
 	return Point64{
-		X: Int64(p.Y*b.Z - p.Z*b.Y), // y * b.z - z * b.y
-		Y: Int64(p.Z*b.X - p.X*b.Z), // z * b.x - x * b.z
-		Z: Int64(p.X*b.Y - p.Y*b.X), // x * b.y - y * b.x
+		X: Int64(p.Y)*Int64(b.Z) - Int64(p.Z)*Int64(b.Y), // y * b.z - z * b.y
+		Y: Int64(p.Z)*Int64(b.X) - Int64(p.X)*Int64(b.Z), // z * b.x - x * b.z
+		Z: Int64(p.X)*Int64(b.Y) - Int64(p.Y)*Int64(b.X), // x * b.y - y * b.x
 	}
 }
 
@@ -29,8 +29,11 @@ func (p *Point32) Cross64(b Point64) Point64 {
 	}
 }
 
+// Dot32 returns the dot product of p and b, multiplying and summing in Int64.
+// Each product fits, but the sum can still overflow: two products of
+// MinInt32 * MinInt32 already add up to 2**63.
 func (p *Point32) Dot32(b Point32) Int64 {
-	return Int64(p.X*b.X + p.Y*b.Y + p.Z*b.Z)
+	return Int64(p.X)*Int64(b.X) + Int64(p.Y)*Int64(b.Y) + Int64(p.Z)*Int64(b.Z)
 }
 
 func (p *Point32) Dot64(b Point64) Int64 {
@@ -45,6 +48,14 @@ func (p *Point32) NotEquals(b Point32) bool {
 	return (p.X != b.X) || (p.Y != b.Y) || (p.Z != b.Z)
 }
 
+// Canonical returns p with its internal index reset to -1, as from NewPoint32.
+// Two points that are Equals compare == after Canonical, so use it when a
+// Point32 is a map key or is otherwise compared with ==; the index is
+// bookkeeping for the hull computation and isn't part of the point's value.
+func (p Point32) Canonical() Point32 {
+	return NewPoint32(p.X, p.Y, p.Z)
+}
+
 func (p *Point32) Add(b Point32) Point32 {
 	return Point32{
 		X: p.X + b.X,
@@ -60,3 +71,31 @@ func (p *Point32) Subtract(b Point32) Point32 {
 		Z: p.Z - b.Z,
 	}
 }
+
+func (p *Point32) Scale(s Int32) Point32 {
+	return Point32{
+		X: p.X * s,
+		Y: p.Y * s,
+		Z: p.Z * s,
+	}
+}
+
+func (p *Point32) Negate() Point32 {
+	return Point32{
+		X: -p.X,
+		Y: -p.Y,
+		Z: -p.Z,
+	}
+}
+
+// LengthSquared returns x*x + y*y + z*z, squaring and summing in Int64. As
+// with Dot32, the sum can overflow: NewPoint32(MinInt32, MinInt32, 0) already
+// has a squared length of 2**63.
+func (p *Point32) LengthSquared() Int64 {
+	return p.Dot32(*p)
+}
+
+// ToVector3 returns p as a floating-point point, with W set to 1.
+func (p Point32) ToVector3() Vector3 {
+	return Vector3{X: float64(p.X), Y: float64(p.Y), Z: float64(p.Z), W: 1}
+}
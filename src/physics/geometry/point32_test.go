@@ -0,0 +1,112 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoint32Canonical(t *testing.T) {
+	a := NewPoint32(1, 2, 3)
+	b := a.Add(NewPoint32(0, 0, 0)) // same coordinates, but index is 0 rather than -1
+	require.True(t, a.Equals(b))
+	require.NotEqual(t, a, b)
+
+	// Deduplicating on the raw value keeps both points:
+	raw := map[Point32]bool{a: true, b: true}
+	require.Len(t, raw, 2)
+
+	canonical := map[Point32]bool{a.Canonical(): true, b.Canonical(): true}
+	require.Len(t, canonical, 1)
+	require.True(t, canonical[NewPoint32(1, 2, 3)])
+
+	// Canonical matches the constructor, whatever the index was before:
+	c := b.Canonical()
+	require.Equal(t, NewPoint32(1, 2, 3), c)
+	require.Equal(t, a, a.Canonical())
+	require.True(t, c.Equals(a))
+}
+
+func TestPoint32Cross32(t *testing.T) {
+	const max, min = math.MaxInt32, math.MinInt32
+
+	for idx, tc := range []struct {
+		a, b Point32
+		out  Point64
+	}{
+		{NewPoint32(1, 0, 0), NewPoint32(0, 1, 0), Point64{0, 0, 1}},
+		{NewPoint32(1, 2, 3), NewPoint32(4, 5, 6), Point64{-3, 6, -3}},
+
+		// Every product here overflows an Int32:
+		{NewPoint32(max, max, max), NewPoint32(max, -max, 1), Point64{max + max*max, max*max - max, -2 * max * max}},
+		{NewPoint32(min, 0, 0), NewPoint32(0, min, min), Point64{0, min * -min, min * min}},
+		{NewPoint32(0, max, min), NewPoint32(0, min, max), Point64{max*max - min*min, 0, 0}},
+	} {
+		a, b := tc.a, tc.b
+		require.Equal(t, tc.out, a.Cross32(b), "%d", idx)
+		require.Equal(t, tc.out, a.Cross64(Point64{Int64(b.X), Int64(b.Y), Int64(b.Z)}), "%d", idx)
+	}
+}
+
+func TestPoint32Dot32(t *testing.T) {
+	const max, min = math.MaxInt32, math.MinInt32
+
+	for idx, tc := range []struct {
+		a, b Point32
+		out  Int64
+	}{
+		{NewPoint32(1, 2, 3), NewPoint32(4, 5, 6), 32},
+		{NewPoint32(1, 0, 0), NewPoint32(0, 1, 0), 0},
+
+		// Every product here overflows an Int32:
+		{NewPoint32(max, max, 0), NewPoint32(max, max, 0), 2 * max * max},
+		{NewPoint32(min, max, 0), NewPoint32(min, -max, 0), min*min - max*max},
+		{NewPoint32(max, min, max), NewPoint32(-max, min, 1), -max*max + min*min + max},
+		{NewPoint32(min, min, 1<<16), NewPoint32(max, max, 1<<16), 2*min*max + 1<<32},
+
+		// The largest sums that still fit:
+		{NewPoint32(min, max, 0), NewPoint32(min, max, 0), min*min + max*max},
+		{NewPoint32(min, min, 0), NewPoint32(-max, -max, 0), -2 * min * max},
+	} {
+		a, b := tc.a, tc.b
+		require.Equal(t, tc.out, a.Dot32(b), "%d", idx)
+		require.Equal(t, tc.out, a.Dot64(Point64{Int64(b.X), Int64(b.Y), Int64(b.Z)}), "%d", idx)
+	}
+
+	// Two products of min * min sum to 2**63, which wraps:
+	a := NewPoint32(min, min, 0)
+	require.Equal(t, Int64(math.MinInt64), a.Dot32(a))
+}
+
+func TestPoint32Algebra(t *testing.T) {
+	const max, min = math.MaxInt32, math.MinInt32
+
+	a := NewPoint32(1, -2, 3)
+	require.Equal(t, NewPoint32(3, -6, 9), a.Scale(3).Canonical())
+	require.Equal(t, a.Negate(), a.Scale(-1))
+	require.Equal(t, NewPoint32(-1, 2, -3), a.Negate().Canonical())
+	require.True(t, a.Scale(0).IsZero())
+	neg := a.Negate()
+	require.Equal(t, a, neg.Negate().Canonical())
+	require.True(t, a.Add(neg).IsZero())
+
+	require.Equal(t, Int64(14), a.LengthSquared())
+	zero := neg.Add(a)
+	require.Equal(t, Int64(0), zero.LengthSquared())
+
+	// The squares overflow an Int32:
+	b := NewPoint32(max, min, 0)
+	require.Equal(t, Int64(max*max+min*min), b.LengthSquared())
+	c := NewPoint32(max, 0, max)
+	require.Equal(t, Int64(2*max*max), c.LengthSquared())
+
+	// Two squares of min sum to 2**63, which wraps:
+	d := NewPoint32(min, min, 0)
+	require.Equal(t, Int64(math.MinInt64), d.LengthSquared())
+}
+
+func TestPoint32ToVector3(t *testing.T) {
+	require.Equal(t, Vector3{X: 1, Y: -2, Z: 3, W: 1}, NewPoint32(1, -2, 3).ToVector3())
+	require.Equal(t, Vector3{X: math.MaxInt32, Y: math.MinInt32, W: 1}, NewPoint32(math.MaxInt32, math.MinInt32, 0).ToVector3())
+}
@@ -10,4 +10,61 @@ func (p Point64) IsZero() bool {
 
 func (p *Point64) Dot(b Point64) Int64 {
 	return p.X * b.X + p.Y * b.Y + p.Z * b.Z
-}
\ No newline at end of file
+}
+
+// Add returns the component-wise sum p + b.
+func (p Point64) Add(b Point64) Point64 {
+	return Point64{
+		X: p.X + b.X,
+		Y: p.Y + b.Y,
+		Z: p.Z + b.Z,
+	}
+}
+
+// Subtract returns the component-wise difference p - b.
+func (p Point64) Subtract(b Point64) Point64 {
+	return Point64{
+		X: p.X - b.X,
+		Y: p.Y - b.Y,
+		Z: p.Z - b.Z,
+	}
+}
+
+// Scale returns p with each component multiplied by s.
+func (p Point64) Scale(s Int64) Point64 {
+	return Point64{
+		X: p.X * s,
+		Y: p.Y * s,
+		Z: p.Z * s,
+	}
+}
+
+// Dot128 returns the dot product of p and b, multiplying and summing in
+// Int128. Each product fits, but the sum can still overflow once two products
+// are close to 2**126: two products of MinInt64 * MinInt64 already add up to
+// 2**127.
+func (p Point64) Dot128(b Point64) Int128 {
+	x := Int128FromInt64(p.X).Mul64(b.X)
+	y := Int128FromInt64(p.Y).Mul64(b.Y)
+	z := Int128FromInt64(p.Z).Mul64(b.Z)
+	return x.Add(y).Add(z)
+}
+
+// Cross128 returns the cross product of p and b as a PointRational128 with a
+// denominator of 1. The products are formed in Int128, where the result is
+// always exact: each difference of two products of Int64s is less than
+// 2**127 in magnitude.
+func (p Point64) Cross128(b Point64) PointRational128 {
+	return NewPointRational128(
+		Int128FromInt64(p.Y).Mul64(b.Z).Sub(Int128FromInt64(p.Z).Mul64(b.Y)), // y * b.z - z * b.y
+		Int128FromInt64(p.Z).Mul64(b.X).Sub(Int128FromInt64(p.X).Mul64(b.Z)), // z * b.x - x * b.z
+		Int128FromInt64(p.X).Mul64(b.Y).Sub(Int128FromInt64(p.Y).Mul64(b.X)), // x * b.y - y * b.x
+		Int128FromInt64(1),
+	)
+}
+
+// ToVector3 returns p as a floating-point point, with W set to 1. Coordinates
+// beyond 2**53 in magnitude are rounded to the nearest float64.
+func (p Point64) ToVector3() Vector3 {
+	return Vector3{X: float64(p.X), Y: float64(p.Y), Z: float64(p.Z), W: 1}
+}
@@ -0,0 +1,110 @@
+package geometry
+
+import (
+	"math"
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randPoint64(rng *mathrand.Rand) Point64 {
+	coord := func() Int64 {
+		switch rng.Intn(4) {
+		case 0:
+			return math.MaxInt64 - Int64(rng.Intn(2))
+		case 1:
+			return math.MinInt64 + Int64(rng.Intn(2))
+		default:
+			return Int64(rng.Uint64())
+		}
+	}
+	return Point64{coord(), coord(), coord()}
+}
+
+// bigMulSub returns a*b - c*d.
+func bigMulSub(a, b, c, d Int64) *big.Int {
+	ab := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+	cd := new(big.Int).Mul(big.NewInt(int64(c)), big.NewInt(int64(d)))
+	return ab.Sub(ab, cd)
+}
+
+func TestPoint64Cross128(t *testing.T) {
+	const max, min = math.MaxInt64, math.MinInt64
+
+	require.Equal(t,
+		NewPointRational128(i64(-3), i64(6), i64(-3), i64(1)),
+		Point64{1, 2, 3}.Cross128(Point64{4, 5, 6}))
+	require.Equal(t,
+		NewPointRational128(i64(0), i64(0), i64(1), i64(1)),
+		Point64{1, 0, 0}.Cross128(Point64{0, 1, 0}))
+
+	// The extremes, where the result needs the full 128 bits:
+	c := Point64{0, min, max}.Cross128(Point64{0, max, min})
+	require.Equal(t, bigMulSub(min, min, max, max).String(), c.X.String())
+	require.Equal(t, i64(0), c.Y)
+	require.Equal(t, i64(0), c.Z)
+
+	rng := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, b := randPoint64(rng), randPoint64(rng)
+		c := a.Cross128(b)
+		require.Equal(t, bigMulSub(a.Y, b.Z, a.Z, b.Y).String(), c.X.String(), "%v x %v", a, b)
+		require.Equal(t, bigMulSub(a.Z, b.X, a.X, b.Z).String(), c.Y.String(), "%v x %v", a, b)
+		require.Equal(t, bigMulSub(a.X, b.Y, a.Y, b.X).String(), c.Z.String(), "%v x %v", a, b)
+		require.Equal(t, i64(1), c.Denominator)
+	}
+}
+
+func TestPoint64Dot128(t *testing.T) {
+	const max, min = math.MaxInt64, math.MinInt64
+
+	require.Equal(t, i64(32), Point64{1, 2, 3}.Dot128(Point64{4, 5, 6}))
+	require.Equal(t, i64(0), Point64{1, 0, 0}.Dot128(Point64{0, 1, 0}))
+
+	// Two extreme products still fit:
+	ref := new(big.Int).Mul(big.NewInt(min), big.NewInt(min))
+	ref.Add(ref, new(big.Int).Mul(big.NewInt(max), big.NewInt(min)))
+	require.Equal(t, ref.String(), Point64{min, max, 0}.Dot128(Point64{min, min, max}).String())
+
+	// Two products of min * min sum to 2**127, which wraps:
+	require.Equal(t, MinInt128, Point64{min, min, 0}.Dot128(Point64{min, min, 0}))
+
+	rng := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, b := randPoint64(rng), randPoint64(rng)
+		ref := new(big.Int)
+		for _, c := range [][2]Int64{{a.X, b.X}, {a.Y, b.Y}, {a.Z, b.Z}} {
+			ref.Add(ref, bigMulSub(c[0], c[1], 0, 0))
+		}
+		if ref.Cmp(maxBigInt128) > 0 || ref.Cmp(minBigInt128) < 0 {
+			continue
+		}
+		require.Equal(t, ref.String(), a.Dot128(b).String(), "%v . %v", a, b)
+	}
+}
+
+func TestPoint64Algebra(t *testing.T) {
+	a := Point64{1, -2, 3}
+	b := Point64{10, 20, -30}
+
+	require.Equal(t, Point64{11, 18, -27}, a.Add(b))
+	require.Equal(t, Point64{-9, -22, 33}, a.Subtract(b))
+	require.Equal(t, Point64{9, 22, -33}, b.Subtract(a))
+	require.Equal(t, Point64{3, -6, 9}, a.Scale(3))
+	require.Equal(t, Point64{-1, 2, -3}, a.Scale(-1))
+	require.True(t, a.Scale(0).IsZero())
+	require.True(t, a.Subtract(a).IsZero())
+
+	// Coordinates beyond the range of a Point32:
+	wide := Point64{1 << 40, -(1 << 40), 1 << 50}
+	require.Equal(t, Point64{1<<40 + 1, -(1 << 40) - 2, 1<<50 + 3}, wide.Add(a))
+	require.Equal(t, Point64{1 << 41, -(1 << 41), 1 << 51}, wide.Scale(2))
+}
+
+func TestPoint64ToVector3(t *testing.T) {
+	require.Equal(t, Vector3{X: 1, Y: -2, Z: 3, W: 1}, Point64{1, -2, 3}.ToVector3())
+	require.Equal(t, Vector3{X: 1 << 53, Y: -(1 << 53), W: 1}, Point64{1 << 53, -(1 << 53), 0}.ToVector3())
+	require.Equal(t, Vector3{X: math.MaxInt64, Y: math.MinInt64, W: 1}, Point64{math.MaxInt64, math.MinInt64, 0}.ToVector3())
+}
@@ -18,4 +18,92 @@ func (r PointRational128) YScalar() Scalar {
 
 func (r PointRational128) ZScalar() Scalar {
 	return r.Z.ToScalar() / r.Denominator.ToScalar()
-}
\ No newline at end of file
+}
+
+// Reduce returns r with X, Y, Z and Denominator divided by their greatest
+// common divisor. The signs are left as they are, so a negative denominator
+// stays negative. A point with every component zero is returned unchanged.
+func (r PointRational128) Reduce() PointRational128 {
+	g := r.X.AbsUint128().GCD(r.Y.AbsUint128()).GCD(r.Z.AbsUint128()).GCD(r.Denominator.AbsUint128())
+	if g.IsZero() || g.Equal64(1) {
+		return r
+	}
+	// g can be 2**127, which isn't an Int128, so the magnitudes are divided
+	// and the signs put back afterwards:
+	quo := func(n Int128) Int128 {
+		sign, mag := n.SignAbs()
+		q := mag.Quo(g).AsInt128()
+		if sign < 0 {
+			return q.Neg()
+		}
+		return q
+	}
+	return PointRational128{X: quo(r.X), Y: quo(r.Y), Z: quo(r.Z), Denominator: quo(r.Denominator)}
+}
+
+// Add returns r + b, exactly. The result's denominator is the least common
+// multiple of the two denominators (up to sign), so points that share a
+// denominator keep it. Both denominators must be non-zero. ok is false if any
+// part of the result overflows an Int128, in which case out is meaningless.
+func (r PointRational128) Add(b PointRational128) (out PointRational128, ok bool) {
+	g := r.Denominator.AbsUint128().GCD(b.Denominator.AbsUint128()).AsInt128()
+	rscale, bscale := b.Denominator.Quo(g), r.Denominator.Quo(g)
+
+	var overflow bool
+	if out.Denominator, overflow = r.Denominator.MulOverflow(rscale); overflow {
+		return out, false
+	}
+	for _, c := range []struct {
+		dest   *Int128
+		rc, bc Int128
+	}{
+		{&out.X, r.X, b.X},
+		{&out.Y, r.Y, b.Y},
+		{&out.Z, r.Z, b.Z},
+	} {
+		rc, overflow1 := c.rc.MulOverflow(rscale)
+		bc, overflow2 := c.bc.MulOverflow(bscale)
+		sum, overflow3 := rc.AddOverflow(bc)
+		if overflow1 || overflow2 || overflow3 {
+			return out, false
+		}
+		*c.dest = sum
+	}
+	return out, true
+}
+
+// Subtract returns r - b, with the same rules as Add.
+func (r PointRational128) Subtract(b PointRational128) (out PointRational128, ok bool) {
+	if b.X == MinInt128 || b.Y == MinInt128 || b.Z == MinInt128 {
+		return out, false
+	}
+	return r.Add(PointRational128{X: b.X.Neg(), Y: b.Y.Neg(), Z: b.Z.Neg(), Denominator: b.Denominator})
+}
+
+// Dot returns the dot product of r and b as an exact Rational128 in lowest
+// terms, built up with Rational128.Mul and Rational128.Add. ok is false if
+// any intermediate result doesn't fit, in which case out is meaningless.
+func (r PointRational128) Dot(b PointRational128) (out Rational128, ok bool) {
+	out = rational128Zero()
+	for _, c := range [][2]Int128{{r.X, b.X}, {r.Y, b.Y}, {r.Z, b.Z}} {
+		product, ok := NewRational128(c[0], r.Denominator).Reduce().Mul(NewRational128(c[1], b.Denominator).Reduce())
+		if !ok {
+			return out, false
+		}
+		if out, ok = out.Add(product); !ok {
+			return out, false
+		}
+	}
+	return out, true
+}
+
+// ToVector3 returns r as a floating-point point, with W set to 1.
+func (r PointRational128) ToVector3() Vector3 {
+	return Vector3{X: float64(r.XScalar()), Y: float64(r.YScalar()), Z: float64(r.ZScalar()), W: 1}
+}
+
+// ApproxEquals reports whether r is within eps of v in each of X, Y and Z, as
+// with Vector3.ApproxEquals.
+func (r PointRational128) ApproxEquals(v Vector3, eps Scalar) bool {
+	return r.ToVector3().ApproxEquals(v, eps)
+}
@@ -0,0 +1,135 @@
+package geometry
+
+import (
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func pr128(x, y, z, den int64) PointRational128 {
+	return NewPointRational128(i64(Int64(x)), i64(Int64(y)), i64(Int64(z)), i64(Int64(den)))
+}
+
+// pr128Big returns the coordinates of r as big.Rats.
+func pr128Big(r PointRational128) [3]*big.Rat {
+	var out [3]*big.Rat
+	for i, c := range []Int128{r.X, r.Y, r.Z} {
+		out[i] = new(big.Rat).SetFrac(c.AsBigInt(), r.Denominator.AsBigInt())
+	}
+	return out
+}
+
+func TestPointRational128Add(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b, sum, diff PointRational128
+	}{
+		{pr128(1, 2, 3, 1), pr128(4, 5, 6, 1), pr128(5, 7, 9, 1), pr128(-3, -3, -3, 1)},
+		{pr128(1, 2, 3, 2), pr128(1, 1, 1, 3), pr128(5, 8, 11, 6), pr128(1, 4, 7, 6)},
+		{pr128(1, 2, 3, 4), pr128(1, 1, 1, 6), pr128(5, 8, 11, 12), pr128(1, 4, 7, 12)},
+		{pr128(1, 2, 3, 4), pr128(1, 1, 1, 4), pr128(2, 3, 4, 4), pr128(0, 1, 2, 4)},
+		{pr128(1, 2, 3, -2), pr128(1, 1, 1, 2), pr128(0, 1, 2, -2), pr128(2, 3, 4, -2)},
+	} {
+		sum, ok := tc.a.Add(tc.b)
+		require.True(t, ok, "%d", idx)
+		require.Equal(t, tc.sum, sum, "%d", idx)
+
+		diff, ok := tc.a.Subtract(tc.b)
+		require.True(t, ok, "%d", idx)
+		require.Equal(t, tc.diff, diff, "%d", idx)
+	}
+
+	_, ok := NewPointRational128(MaxInt128, i64(0), i64(0), i64(1)).Add(pr128(1, 0, 0, 1))
+	require.False(t, ok)
+	_, ok = pr128(1, 1, 1, 3).Add(NewPointRational128(i64(1), i64(1), i64(1), MaxInt128))
+	require.False(t, ok)
+	_, ok = pr128(0, 0, 0, 1).Subtract(NewPointRational128(MinInt128, i64(0), i64(0), i64(1)))
+	require.False(t, ok)
+}
+
+func TestPointRational128AddRandom(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(1))
+	rnd := func() int64 { return rng.Int63n(1<<40) - 1<<39 }
+	for i := 0; i < 5000; i++ {
+		den1, den2 := rng.Int63n(1<<20)+1, rng.Int63n(1<<20)+1
+		a := pr128(rnd(), rnd(), rnd(), den1)
+		b := pr128(rnd(), rnd(), rnd(), den2)
+		ra, rb := pr128Big(a), pr128Big(b)
+
+		sum, ok := a.Add(b)
+		require.True(t, ok)
+		diff, ok := a.Subtract(b)
+		require.True(t, ok)
+		rsum, rdiff := pr128Big(sum), pr128Big(diff)
+		for c := 0; c < 3; c++ {
+			require.Equal(t, new(big.Rat).Add(ra[c], rb[c]).String(), rsum[c].String())
+			require.Equal(t, new(big.Rat).Sub(ra[c], rb[c]).String(), rdiff[c].String())
+		}
+
+		dot, ok := a.Dot(b)
+		require.True(t, ok)
+		ref := new(big.Rat)
+		for c := 0; c < 3; c++ {
+			ref.Add(ref, new(big.Rat).Mul(ra[c], rb[c]))
+		}
+		require.Equal(t, ref.String(), dot.AsBigRat().String())
+	}
+}
+
+func TestPointRational128Dot(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b PointRational128
+		dot  string
+	}{
+		{pr128(1, 2, 3, 1), pr128(4, 5, 6, 1), "32"},
+		{pr128(1, 2, 3, 2), pr128(4, 5, 6, 3), "16/3"},
+		{pr128(1, 0, 0, 2), pr128(0, 1, 0, 3), "0"},
+		{pr128(1, 1, 1, -2), pr128(1, 1, 1, 2), "-3/4"},
+	} {
+		dot, ok := tc.a.Dot(tc.b)
+		require.True(t, ok, "%d", idx)
+		require.Equal(t, tc.dot, dot.String(), "%d", idx)
+	}
+
+	_, ok := NewPointRational128(MaxInt128, i64(0), i64(0), i64(1)).Dot(NewPointRational128(MaxInt128, i64(0), i64(0), i64(1)))
+	require.False(t, ok)
+}
+
+func TestPointRational128Reduce(t *testing.T) {
+	require.Equal(t, pr128(1, 2, 3, 4), pr128(6, 12, 18, 24).Reduce())
+	require.Equal(t, pr128(-1, 2, 0, -4), pr128(-6, 12, 0, -24).Reduce())
+	require.Equal(t, pr128(1, 2, 3, 5), pr128(1, 2, 3, 5).Reduce())
+	require.Equal(t, pr128(0, 0, 0, 1), pr128(0, 0, 0, 7).Reduce())
+	require.Equal(t, pr128(0, 0, 0, 0), pr128(0, 0, 0, 0).Reduce())
+
+	// A large common factor, well beyond 64 bits:
+	f := i128s("0x1234567890ABCDEF1234567890AB")
+	p := NewPointRational128(f.Mul64(3), f.Mul64(-5), f.Mul64(7), f.Mul64(11))
+	require.Equal(t, pr128(3, -5, 7, 11), p.Reduce())
+
+	// A common factor of 2**127 mustn't flip the signs:
+	m := NewPointRational128(MinInt128, i64(0), MinInt128, MinInt128)
+	require.Equal(t, pr128(-1, 0, -1, -1), m.Reduce())
+	m = NewPointRational128(MinInt128, i64(0), i64(0), MinInt128)
+	require.Equal(t, pr128(-1, 0, 0, -1), m.Reduce())
+}
+
+func TestPointRational128ToVector3(t *testing.T) {
+	p := pr128(1, -2, 7, 3)
+	v := p.ToVector3()
+	require.Equal(t, float64(1), v.W)
+
+	exact := pr128Big(p)
+	for i, f := range []float64{v.X, v.Y, v.Z} {
+		rf := new(big.Float).SetRat(exact[i])
+		diff := new(big.Float).Sub(rf, new(big.Float).SetFloat64(f))
+		pct := new(big.Float).Quo(diff, rf)
+		require.True(t, pct.Abs(pct).Cmp(floatDiffLimit) < 0, "%d: %s vs %.20f", i, exact[i], f)
+	}
+
+	require.True(t, p.ApproxEquals(Vector3{X: 1.0 / 3, Y: -2.0 / 3, Z: 7.0 / 3}, Epsilon))
+	require.True(t, p.ApproxEquals(Vector3{X: 0.3333333, Y: -0.6666667, Z: 2.3333333}, Epsilon))
+	require.False(t, p.ApproxEquals(Vector3{X: 0.333, Y: -0.667, Z: 2.333}, Epsilon))
+	require.True(t, p.ApproxEquals(Vector3{X: 0.333, Y: -0.667, Z: 2.333}, 1e-3))
+}
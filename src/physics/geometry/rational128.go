@@ -1,22 +1,26 @@
 package geometry
 
+import (
+	"fmt"
+	"math/big"
+)
+
+// NewRational128 returns numerator/denominator, with the sign of the result
+// held in the sign field and the numerator and denominator stored as
+// magnitudes. A zero numerator gives zero (sign 0), and a zero denominator
+// gives an infinity with the sign of the numerator, or NaN for 0/0.
+//
+// The fraction is not reduced; use Reduce if that's needed.
 func NewRational128(numerator Int128, denominator Int128) Rational128 {
 	r := Rational128{}
-	sign := numerator.Sign()
-	if sign >= 0 {
-		r.numerator = numerator
-	} else {
-		// swap the sign
-		r.numerator = numerator.Mul64(-1)
-	}
+	r.sign = numerator.Sign()
+	r.numerator = numerator.Abs() // MinInt128 stays as is, which AbsUint128 reads back as 2**127
 
-	dsign := denominator.Sign()
-	if dsign >= 0 {
-		r.denominator = denominator
+	if denominator.Sign() < 0 {
+		r.sign = -r.sign
+		r.denominator = denominator.Abs()
 	} else {
-		r.sign = -sign
-		// swap the sign
-		r.denominator = denominator.Mul64(-1)
+		r.denominator = denominator
 	}
 	r.isInt64 = false
 	return r
@@ -47,10 +51,334 @@ type Rational128 struct {
 	isInt64 bool
 }
 
+// AsRational64 narrows r to a Rational64, with ok set to false if the
+// magnitude of the numerator or denominator doesn't fit in a Uint64. r is
+// not reduced first; use Reduce if r may not be in lowest terms.
+func (r Rational128) AsRational64() (out Rational64, ok bool) {
+	num, den := r.numerator.AbsUint128(), r.denominator.AbsUint128()
+	if !num.IsUint64() || !den.IsUint64() {
+		return out, false
+	}
+	return Rational64{numerator: num.lo, denominator: den.lo, sign: r.sign}, true
+}
+
+// IsNaN reports whether r is the 0/0 sentinel.
+func (r Rational128) IsNaN() bool {
+	return (r.sign == 0) && r.denominator.IsZero()
+}
+
+// IsInfinity reports whether r is positive or negative infinity.
+func (r Rational128) IsInfinity() bool {
+	return (r.sign != 0) && r.denominator.IsZero()
+}
+
+// IsNegativeInfinity reports whether r is negative infinity.
+func (r Rational128) IsNegativeInfinity() bool {
+	return (r.sign < 0) && r.denominator.IsZero()
+}
+
+// String returns r exactly as "num/den", or just "num" if the denominator is
+// 1, with a leading '-' if r is negative. Zero is always "0", and the
+// degenerate values are "+Inf", "-Inf" and "NaN".
+func (r Rational128) String() string {
+	switch {
+	case r.IsNaN():
+		return "NaN"
+	case r.IsNegativeInfinity():
+		return "-Inf"
+	case r.IsInfinity():
+		return "+Inf"
+	case r.isZero():
+		return "0"
+	}
+	num := r.numerator.AbsUint128().text(10, r.sign < 0)
+	den := r.denominator.AbsUint128()
+	if den.Equal64(1) {
+		return num
+	}
+	return num + "/" + den.String()
+}
+
+// Rational128FromBigRat converts q to a Rational128. If the numerator or
+// denominator of q doesn't fit in an Int128, ok is false and r is meaningless.
+// Like big.Rat itself, the result is always in lowest terms.
+func Rational128FromBigRat(q *big.Rat) (r Rational128, ok bool) {
+	num, ok := Int128FromBigInt(q.Num())
+	if !ok {
+		return r, false
+	}
+	den, ok := Int128FromBigInt(q.Denom())
+	if !ok {
+		return r, false
+	}
+	return NewRational128(num, den), true
+}
+
+// AsBigRat returns r as a big.Rat. big.Rat can't represent infinities or NaN,
+// so AsBigRat panics if the denominator is zero.
+func (r Rational128) AsBigRat() *big.Rat {
+	if r.denominator.IsZero() {
+		panic(fmt.Errorf("num: Rational128 %s has no big.Rat equivalent", r))
+	}
+	q := new(big.Rat)
+	if r.isZero() {
+		return q
+	}
+	q.SetFrac(r.numerator.AbsUint128().AsBigInt(), r.denominator.AbsUint128().AsBigInt())
+	if r.sign < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
 func (r *Rational128) ToScalar() Scalar {
 	if r.denominator.Sign() == 0 {
 		return Scalar(float64(r.sign) * Infinity)
 	} else {
-		return Scalar(r.sign) * r.numerator.ToScalar() / r.denominator.ToScalar()
+		// The magnitudes are read back unsigned, as MinInt128 stands for 2**127:
+		num, den := r.numerator.AbsUint128().AsFloat64(), r.denominator.AbsUint128().AsFloat64()
+		return Scalar(r.sign) * Scalar(num) / Scalar(den)
+	}
+}
+
+// CmpInt128 compares r and n exactly, returning -1 if r < n, 0 if r == n and
+// +1 if r > n. Positive and negative infinity compare above and below every
+// Int128. A NaN r compares equal to every n.
+func (r Rational128) CmpInt128(n Int128) int {
+	nsign, nmag := n.SignAbs()
+	if r.denominator.IsZero() {
+		return r.sign
+	}
+	num := r.numerator.AbsUint128()
+	if r.sign == 0 || num.IsZero() {
+		return -nsign
+	}
+	if r.sign != nsign {
+		if r.sign > nsign {
+			return 1
+		}
+		return -1
+	}
+
+	// Same sign, so compare the magnitudes num/den and |n| by cross-multiplying;
+	// |n| * den needs up to 255 bits:
+	hi, lo := nmag.MulFull(r.denominator.AbsUint128())
+	cmp := -1
+	if hi.IsZero() {
+		cmp = num.Cmp(lo)
+	}
+	return cmp * r.sign
+}
+
+// CmpRational128 compares i and r exactly; it is the mirror of
+// Rational128.CmpInt128, returning -1 if i < r, 0 if i == r and +1 if i > r.
+func (i Int128) CmpRational128(r Rational128) int {
+	return -r.CmpInt128(i)
+}
+
+// Reduce returns r in lowest terms, with the numerator and denominator divided
+// by their greatest common divisor and the sign held only in the sign field.
+// Zero is always reduced to 0/1.
+//
+// A zero denominator is left untouched, as it marks an infinity or NaN rather
+// than a fraction.
+func (r Rational128) Reduce() Rational128 {
+	if r.denominator.IsZero() {
+		return r
+	}
+	if r.sign == 0 || r.numerator.IsZero() {
+		out := rational128Zero()
+		out.isInt64 = r.isInt64
+		return out
+	}
+	num, den := r.numerator.AbsUint128(), r.denominator.AbsUint128()
+	g := num.GCD(den)
+	r.numerator, r.denominator = num.Quo(g).AsInt128(), den.Quo(g).AsInt128()
+	return r
+}
+
+// Add returns r + n. The arithmetic is exact, but ok is false if the numerator
+// or denominator of the result doesn't fit in an Int128, in which case out is
+// meaningless.
+//
+// Infinities and NaN follow the usual rules: NaN + n and Inf + -Inf are NaN,
+// and Inf + n is Inf for any other n.
+//
+// The sum is found using the method from Knuth's TAOCP 4.5.1, which only
+// divides out factors common to the denominators, keeping the intermediate
+// numerator to 256 bits. If r and n are both in lowest terms, so is the
+// result, and ok is only false if the result can't be represented at all.
+func (r Rational128) Add(n Rational128) (out Rational128, ok bool) {
+	switch {
+	case r.IsNaN() || n.IsNaN():
+		return Rational128{}, true
+	case r.IsInfinity():
+		if n.IsInfinity() && n.sign != r.sign {
+			return Rational128{}, true
+		}
+		return rational128Inf(r.sign), true
+	case n.IsInfinity():
+		return rational128Inf(n.sign), true
+	case r.isZero():
+		return rational128FromMagnitudes(n.sign, n.numerator.AbsUint128(), n.denominator.AbsUint128())
+	case n.isZero():
+		return rational128FromMagnitudes(r.sign, r.numerator.AbsUint128(), r.denominator.AbsUint128())
+	}
+
+	rnum, rden := r.numerator.AbsUint128(), r.denominator.AbsUint128()
+	nnum, nden := n.numerator.AbsUint128(), n.denominator.AbsUint128()
+
+	g := rden.GCD(nden)
+	rdeng, ndeng := rden.Quo(g), nden.Quo(g)
+
+	// t = rnum*ndeng ± nnum*rdeng, in 256 bits:
+	t1hi, t1lo := rnum.MulFull(ndeng)
+	t2hi, t2lo := nnum.MulFull(rdeng)
+	var thi, tlo Uint128
+	sign := r.sign
+	if r.sign == n.sign {
+		var carry, overflow bool
+		tlo, carry = t1lo.AddOverflow(t2lo)
+		thi, overflow = t1hi.AddOverflow(t2hi)
+		if carry {
+			thi = thi.Inc()
+			overflow = overflow || thi.IsZero()
+		}
+		if overflow {
+			return Rational128{}, false
+		}
+	} else {
+		cmp := t1hi.Cmp(t2hi)
+		if cmp == 0 {
+			cmp = t1lo.Cmp(t2lo)
+		}
+		if cmp == 0 {
+			return rational128Zero(), true
+		} else if cmp < 0 {
+			t1hi, t1lo, t2hi, t2lo = t2hi, t2lo, t1hi, t1lo
+			sign = n.sign
+		}
+		var borrow bool
+		tlo, borrow = t1lo.SubOverflow(t2lo)
+		thi = t1hi.Sub(t2hi)
+		if borrow {
+			thi = thi.Dec()
+		}
+	}
+
+	// Any factor common to t and the new denominator rdeng*nden must divide g:
+	g2 := g
+	if !g.Equal64(1) {
+		_, rem := quoRem256by128(thi.Rem(g), tlo, g)
+		g2 = rem.GCD(g)
+	}
+	if !thi.LessThan(g2) {
+		return Rational128{}, false // The numerator needs more than 128 bits
+	}
+	num, _ := quoRem256by128(thi, tlo, g2)
+	den, overflow := rdeng.MulOverflow(nden.Quo(g2))
+	if overflow {
+		return Rational128{}, false
+	}
+	return rational128FromMagnitudes(sign, num, den)
+}
+
+// Sub returns r - n, with the same rules as Add.
+func (r Rational128) Sub(n Rational128) (out Rational128, ok bool) {
+	return r.Add(n.Neg())
+}
+
+// Mul returns r * n. The arithmetic is exact, but ok is false if the numerator
+// or denominator of the result doesn't fit in an Int128, in which case out is
+// meaningless.
+//
+// NaN * n and Inf * 0 are NaN, and Inf * n is Inf with the product of the
+// signs for any other n.
+//
+// Factors common to each numerator and the other denominator are divided out
+// before multiplying, so if r and n are both in lowest terms, so is the
+// result, and ok is only false if the result can't be represented at all.
+func (r Rational128) Mul(n Rational128) (out Rational128, ok bool) {
+	switch {
+	case r.IsNaN() || n.IsNaN():
+		return Rational128{}, true
+	case r.IsInfinity() || n.IsInfinity():
+		if r.isZero() || n.isZero() {
+			return Rational128{}, true
+		}
+		return rational128Inf(r.sign * n.sign), true
+	case r.isZero() || n.isZero():
+		return rational128Zero(), true
+	}
+
+	rnum, rden := r.numerator.AbsUint128(), r.denominator.AbsUint128()
+	nnum, nden := n.numerator.AbsUint128(), n.denominator.AbsUint128()
+
+	g1, g2 := rnum.GCD(nden), nnum.GCD(rden)
+	num, overflow := rnum.Quo(g1).MulOverflow(nnum.Quo(g2))
+	if overflow {
+		return Rational128{}, false
+	}
+	den, overflow := rden.Quo(g2).MulOverflow(nden.Quo(g1))
+	if overflow {
+		return Rational128{}, false
+	}
+	return rational128FromMagnitudes(r.sign*n.sign, num, den)
+}
+
+// Div returns r / n, with the same rules and precision as Mul. Dividing a
+// non-zero r by zero gives Inf with the sign of r; 0/0 and Inf/Inf are NaN.
+func (r Rational128) Div(n Rational128) (out Rational128, ok bool) {
+	return r.Mul(n.Inverse())
+}
+
+// Neg returns -r. Zero and NaN are unchanged.
+func (r Rational128) Neg() Rational128 {
+	r.sign = -r.sign
+	return r
+}
+
+// Inverse returns 1/r, swapping the numerator and denominator and keeping the
+// sign. The inverse of zero is positive infinity, the inverse of either
+// infinity is zero, and the inverse of NaN is NaN.
+func (r Rational128) Inverse() Rational128 {
+	switch {
+	case r.IsNaN():
+		return Rational128{}
+	case r.IsInfinity():
+		return rational128Zero()
+	case r.isZero():
+		return rational128Inf(1)
+	}
+	r.numerator, r.denominator = r.denominator, r.numerator
+	r.isInt64 = false
+	return r
+}
+
+// isZero reports whether r is zero for the arithmetic methods. A sign of 0
+// means zero for any non-zero denominator, and a zero numerator means zero
+// whatever the sign.
+func (r Rational128) isZero() bool {
+	return !r.denominator.IsZero() && (r.sign == 0 || r.numerator.IsZero())
+}
+
+func rational128Zero() Rational128 {
+	return Rational128{denominator: Int128{lo: 1}}
+}
+
+func rational128Inf(sign int) Rational128 {
+	return Rational128{sign: sign, numerator: Int128{lo: 1}}
+}
+
+// rational128FromMagnitudes builds a Rational128 from a sign and the unsigned
+// magnitudes of its numerator and denominator, with ok set to false if either
+// doesn't fit in an Int128.
+func rational128FromMagnitudes(sign int, num, den Uint128) (r Rational128, ok bool) {
+	if !num.IsInt128() || !den.IsInt128() {
+		return r, false
+	}
+	if num.IsZero() {
+		return rational128Zero(), true
 	}
+	return Rational128{sign: sign, numerator: num.AsInt128(), denominator: den.AsInt128()}, true
 }
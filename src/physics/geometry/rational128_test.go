@@ -0,0 +1,407 @@
+package geometry
+
+import (
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func rat128(sign int, num, den Int128) Rational128 {
+	return Rational128{numerator: num, denominator: den, sign: sign}
+}
+
+func TestRational128CmpInt128(t *testing.T) {
+	for idx, tc := range []struct {
+		r   Rational128
+		n   Int128
+		cmp int
+	}{
+		{rat128(1, i64(1), i64(2)), i64(0), 1},
+		{rat128(1, i64(1), i64(2)), i64(1), -1},
+		{rat128(-1, i64(1), i64(2)), i64(0), -1},
+		{rat128(-1, i64(1), i64(2)), i64(-1), 1},
+		{rat128(1, i64(4), i64(2)), i64(2), 0},
+		{rat128(-1, i64(4), i64(2)), i64(-2), 0},
+		{rat128(0, i64(0), i64(1)), i64(0), 0},
+		{rat128(0, i64(0), i64(1)), i64(-1), 1},
+		{rat128(0, i64(0), i64(1)), i64(1), -1},
+
+		// Infinities:
+		{rat128(1, i64(1), i64(0)), MaxInt128, 1},
+		{rat128(-1, i64(1), i64(0)), MinInt128, -1},
+
+		// |n| * den overflows 128 bits:
+		{rat128(1, MaxInt128, MaxInt128), MaxInt128, -1},
+		{rat128(-1, MaxInt128, MaxInt128), MinInt128, 1},
+		{rat128(1, MaxInt128, i64(1)), MaxInt128, 0},
+		{rat128(1, MaxInt128, i64(2)), i128s("0x3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"), 1},
+		{rat128(1, MaxInt128, i64(2)), i128s("0x40000000000000000000000000000000"), -1},
+	} {
+		t.Run(fmt.Sprintf("%d/%d*%s/%s<=>%s", idx, tc.r.sign, tc.r.numerator, tc.r.denominator, tc.n), func(t *testing.T) {
+			require.Equal(t, tc.cmp, tc.r.CmpInt128(tc.n))
+			require.Equal(t, -tc.cmp, tc.n.CmpRational128(tc.r))
+		})
+	}
+}
+
+func TestRational128CmpInt128Random(t *testing.T) {
+	bts := make([]byte, 16)
+	for i := 0; i < 10000; i++ {
+		num, den, n := randInt128(bts).Abs(), randInt128(bts).Abs(), randInt128(bts)
+		if den.IsZero() || num == MinInt128 || den == MinInt128 {
+			continue
+		}
+		sign := 1
+		if i%2 == 0 {
+			sign = -1
+		}
+		if num.IsZero() {
+			sign = 0
+		}
+
+		r := rat128(sign, num, den)
+		ref := new(big.Rat).SetFrac(num.AsBigInt(), den.AsBigInt())
+		if sign < 0 {
+			ref.Neg(ref)
+		}
+		expected := ref.Cmp(new(big.Rat).SetInt(n.AsBigInt()))
+		require.Equal(t, expected, r.CmpInt128(n), "%d*%s/%s <=> %s", sign, num, den, n)
+		require.Equal(t, -expected, n.CmpRational128(r))
+	}
+}
+
+func TestRational128CmpInt128Small(t *testing.T) {
+	// Random values are almost never equal, so cover the ties exhaustively:
+	for sign := -1; sign <= 1; sign++ {
+		for num := int64(0); num <= 12; num++ {
+			for den := int64(1); den <= 4; den++ {
+				for n := int64(-4); n <= 4; n++ {
+					s := sign
+					if num == 0 {
+						s = 0
+					}
+					ref := big.NewRat(int64(s)*num, den)
+					expected := ref.Cmp(new(big.Rat).SetInt64(n))
+					require.Equal(t, expected, rat128(s, i64(Int64(num)), i64(Int64(den))).CmpInt128(i64(Int64(n))), "%d*%d/%d <=> %d", s, num, den, n)
+				}
+			}
+		}
+	}
+}
+
+func TestNewRational128(t *testing.T) {
+	for idx, tc := range []struct {
+		num, den Int128
+		out      Rational128
+	}{
+		{i64(3), i64(4), rat128(1, i64(3), i64(4))},
+		{i64(-3), i64(4), rat128(-1, i64(3), i64(4))},
+		{i64(3), i64(-4), rat128(-1, i64(3), i64(4))},
+		{i64(-3), i64(-4), rat128(1, i64(3), i64(4))},
+		{i64(6), i64(4), rat128(1, i64(6), i64(4))}, // Not reduced
+		{MaxInt128, MinInt128, rat128(-1, MaxInt128, MinInt128)},
+		{MinInt128, i64(-1), rat128(1, MinInt128, i64(1))},
+
+		// Zeros:
+		{i64(0), i64(4), rat128(0, i64(0), i64(4))},
+		{i64(0), i64(-4), rat128(0, i64(0), i64(4))},
+		{i64(3), i64(0), rat128(1, i64(3), i64(0))},
+		{i64(-3), i64(0), rat128(-1, i64(3), i64(0))},
+		{i64(0), i64(0), rat128(0, i64(0), i64(0))},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.num, tc.den), func(t *testing.T) {
+			r := NewRational128(tc.num, tc.den)
+			require.Equal(t, tc.out, r)
+			if !tc.den.IsZero() {
+				ref := new(big.Rat).SetFrac(tc.num.AsBigInt(), tc.den.AsBigInt())
+				require.Equal(t, ref.String(), r.AsBigRat().String())
+			}
+		})
+	}
+}
+
+func TestRational128ToScalar(t *testing.T) {
+	for idx, tc := range []struct {
+		r   Rational128
+		out Scalar
+	}{
+		{NewRational128(i64(3), i64(4)), 0.75},
+		{NewRational128(i64(-3), i64(4)), -0.75},
+		{NewRational128(i64(3), i64(-4)), -0.75},
+		{NewRational128(i64(0), i64(4)), 0},
+		{NewRational128(i64(1), i64(0)), Infinity},
+		{NewRational128(i64(-1), i64(0)), -Infinity},
+
+		// MinInt128 is held as a magnitude of 2**127:
+		{NewRational128(MinInt128, i64(1)), -0x1p127},
+		{NewRational128(MinInt128, i64(-1)), 0x1p127},
+		{NewRational128(i64(1), MinInt128), -0x1p-127},
+		{NewRational128(i64(-1), MinInt128), 0x1p-127},
+		{NewRational128(MinInt128, MinInt128), 1},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.r), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.r.ToScalar())
+		})
+	}
+}
+
+func TestRational128Special(t *testing.T) {
+	for idx, tc := range []struct {
+		r                Rational128
+		nan, inf, neginf bool
+	}{
+		{NewRational128(i64(1), i64(2)), false, false, false},
+		{NewRational128(i64(-1), i64(2)), false, false, false},
+		{NewRational128(i64(0), i64(2)), false, false, false},
+		{NewRational128(i64(1), i64(0)), false, true, false},
+		{NewRational128(i64(-1), i64(0)), false, true, true},
+		{NewRational128(i64(0), i64(0)), true, false, false},
+		{Rational128{}, true, false, false},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			require.Equal(t, tc.nan, tc.r.IsNaN())
+			require.Equal(t, tc.inf, tc.r.IsInfinity())
+			require.Equal(t, tc.neginf, tc.r.IsNegativeInfinity())
+		})
+	}
+}
+
+func TestRational128String(t *testing.T) {
+	for idx, tc := range []struct {
+		r   Rational128
+		out string
+	}{
+		{NewRational128(i64(3), i64(4)), "3/4"},
+		{NewRational128(i64(-3), i64(4)), "-3/4"},
+		{NewRational128(i64(3), i64(-4)), "-3/4"},
+		{NewRational128(i64(6), i64(4)), "6/4"},
+		{NewRational128(i64(-7), i64(1)), "-7"},
+		{NewRational128(i64(0), i64(5)), "0"},
+		{NewRational128(MinInt128, i64(1)), "-170141183460469231731687303715884105728"},
+		{NewRational128(MinInt128, MinInt128), "170141183460469231731687303715884105728/170141183460469231731687303715884105728"},
+		{NewRational128(i64(1), MaxInt128), "1/170141183460469231731687303715884105727"},
+		{NewRational128(i64(1), i64(0)), "+Inf"},
+		{NewRational128(i64(-1), i64(0)), "-Inf"},
+		{NewRational128(i64(0), i64(0)), "NaN"},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.r.String())
+			require.Equal(t, tc.out, fmt.Sprint(tc.r))
+		})
+	}
+}
+
+func TestRational128NegInverse(t *testing.T) {
+	for idx, tc := range []struct {
+		r, neg, inv Rational128
+	}{
+		{NewRational128(i64(3), i64(4)), NewRational128(i64(-3), i64(4)), NewRational128(i64(4), i64(3))},
+		{NewRational128(i64(-3), i64(4)), NewRational128(i64(3), i64(4)), NewRational128(i64(-4), i64(3))},
+		{NewRational128(i64(1), MaxInt128), NewRational128(i64(-1), MaxInt128), NewRational128(MaxInt128, i64(1))},
+		{NewRational128(i64(0), i64(4)), NewRational128(i64(0), i64(4)), NewRational128(i64(1), i64(0))},
+		{NewRational128(i64(1), i64(0)), NewRational128(i64(-1), i64(0)), NewRational128(i64(0), i64(1))},
+		{NewRational128(i64(-1), i64(0)), NewRational128(i64(1), i64(0)), NewRational128(i64(0), i64(1))},
+		{NewRational128(i64(0), i64(0)), NewRational128(i64(0), i64(0)), NewRational128(i64(0), i64(0))},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.r), func(t *testing.T) {
+			require.Equal(t, tc.neg, tc.r.Neg())
+			require.Equal(t, tc.inv, tc.r.Inverse())
+			require.Equal(t, tc.r, tc.r.Neg().Neg())
+		})
+	}
+}
+
+func TestRational128BigRat(t *testing.T) {
+	for idx, tc := range []struct {
+		q  string
+		ok bool
+	}{
+		{"3/4", true},
+		{"-3/4", true},
+		{"6/4", true}, // big.Rat reduces this to 3/2
+		{"0", true},
+		{"170141183460469231731687303715884105727", true},
+		{"-170141183460469231731687303715884105728", true},
+		{"1/170141183460469231731687303715884105727", true},
+		{"-1/170141183460469231731687303715884105727", true},
+		{"170141183460469231731687303715884105728", false},
+		{"1/170141183460469231731687303715884105728", false},
+		{"-1/170141183460469231731687303715884105728", false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.q), func(t *testing.T) {
+			q, _ := new(big.Rat).SetString(tc.q)
+			r, ok := Rational128FromBigRat(q)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, q.String(), r.AsBigRat().String())
+				require.Equal(t, q.RatString(), r.String())
+			}
+		})
+	}
+
+	require.Panics(t, func() { NewRational128(i64(1), i64(0)).AsBigRat() })
+	require.Panics(t, func() { NewRational128(i64(0), i64(0)).AsBigRat() })
+}
+
+func TestRational128Reduce(t *testing.T) {
+	for idx, tc := range []struct {
+		in, out Rational128
+	}{
+		{rat128(1, i64(6), i64(4)), rat128(1, i64(3), i64(2))},
+		{rat128(-1, i64(6), i64(4)), rat128(-1, i64(3), i64(2))},
+		{rat128(1, i64(-6), i64(-4)), rat128(1, i64(3), i64(2))},
+		{rat128(1, i64(3), i64(2)), rat128(1, i64(3), i64(2))},
+		{rat128(1, i64(7), i64(7)), rat128(1, i64(1), i64(1))},
+		{rat128(0, i64(0), i64(5)), rat128(0, i64(0), i64(1))},
+		{rat128(1, MaxInt128, MaxInt128), rat128(1, i64(1), i64(1))},
+		{rat128(-1, MinInt128, i64(4)), rat128(-1, i128s("0x20000000000000000000000000000000"), i64(1))},
+		{rat128(1, MinInt128, i64(3)), rat128(1, MinInt128, i64(3))}, // 2**127/3 stays as the AbsUint128 magnitude
+
+		// Zero denominators are left alone:
+		{rat128(1, i64(5), i64(0)), rat128(1, i64(5), i64(0))},
+		{rat128(0, i64(0), i64(0)), rat128(0, i64(0), i64(0))},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.in.Reduce())
+		})
+	}
+}
+
+// randRat128 returns a random finite Rational128 in lowest terms, with
+// magnitudes of up to 127 bits chosen so that a good share of the sums and
+// products still fit.
+func randRat128(rng *mathrand.Rand, bts []byte) Rational128 {
+	for {
+		num := randUint128(bts).Rsh(1 + uint(rng.Intn(127)))
+		den := randUint128(bts).Rsh(1 + uint(rng.Intn(127)))
+		if den.IsZero() {
+			continue
+		}
+		g := num.GCD(den)
+		num, den = num.Quo(g), den.Quo(g)
+		sign := 1 - 2*rng.Intn(2)
+		if num.IsZero() {
+			sign, den = 0, u64(1)
+		}
+		return rat128(sign, num.AsInt128(), den.AsInt128())
+	}
+}
+
+func TestRational128Arith(t *testing.T) {
+	var (
+		nan    = Rational128{}
+		inf    = rat128(1, i64(1), i64(0))
+		ninf   = rat128(-1, i64(1), i64(0))
+		zero   = rat128(0, i64(0), i64(1))
+		half   = rat128(1, i64(1), i64(2))
+		nhalf  = rat128(-1, i64(1), i64(2))
+		third  = rat128(1, i64(1), i64(3))
+		maxr   = rat128(1, MaxInt128, i64(1))
+		minmax = rat128(1, i64(1), MaxInt128)
+	)
+
+	type op func(a, b Rational128) (Rational128, bool)
+	add, sub, mul, div := Rational128.Add, Rational128.Sub, Rational128.Mul, Rational128.Div
+
+	for idx, tc := range []struct {
+		op   op
+		name string
+		a, b Rational128
+		out  Rational128
+		ok   bool
+	}{
+		{add, "+", half, third, rat128(1, i64(5), i64(6)), true},
+		{add, "+", half, nhalf, zero, true},
+		{add, "+", nhalf, third, rat128(-1, i64(1), i64(6)), true},
+		{add, "+", half, half, rat128(1, i64(1), i64(1)), true},
+		{add, "+", zero, third, third, true},
+		{add, "+", third, zero, third, true},
+		{add, "+", maxr, maxr, Rational128{}, false},
+		{add, "+", minmax, minmax, rat128(1, i64(2), MaxInt128), true},
+		{sub, "-", half, third, rat128(1, i64(1), i64(6)), true},
+		{sub, "-", third, half, rat128(-1, i64(1), i64(6)), true},
+		{sub, "-", half, half, zero, true},
+		{mul, "*", half, third, rat128(1, i64(1), i64(6)), true},
+		{mul, "*", nhalf, nhalf, rat128(1, i64(1), i64(4)), true},
+		{mul, "*", half, zero, zero, true},
+		{mul, "*", maxr, minmax, rat128(1, i64(1), i64(1)), true},
+		{mul, "*", maxr, maxr, Rational128{}, false},
+		{div, "/", half, third, rat128(1, i64(3), i64(2)), true},
+		{div, "/", nhalf, half, rat128(-1, i64(1), i64(1)), true},
+		{div, "/", zero, half, zero, true},
+		{div, "/", maxr, minmax, Rational128{}, false},
+
+		// Special values:
+		{add, "+", inf, half, inf, true},
+		{add, "+", half, ninf, ninf, true},
+		{add, "+", inf, inf, inf, true},
+		{add, "+", inf, ninf, nan, true},
+		{sub, "-", inf, inf, nan, true},
+		{add, "+", nan, half, nan, true},
+		{mul, "*", inf, nhalf, ninf, true},
+		{mul, "*", ninf, ninf, inf, true},
+		{mul, "*", inf, zero, nan, true},
+		{mul, "*", half, nan, nan, true},
+		{div, "/", half, zero, inf, true},
+		{div, "/", nhalf, zero, ninf, true},
+		{div, "/", zero, zero, nan, true},
+		{div, "/", half, inf, zero, true},
+		{div, "/", inf, ninf, nan, true},
+		{div, "/", ninf, half, ninf, true},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.name), func(t *testing.T) {
+			out, ok := tc.op(tc.a, tc.b)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, tc.out, out)
+			}
+		})
+	}
+}
+
+func TestRational128ArithRandom(t *testing.T) {
+	fits := func(x *big.Rat) bool {
+		return x.Num().CmpAbs(maxBigInt128) <= 0 && x.Denom().Cmp(maxBigInt128) <= 0
+	}
+
+	rng := mathrand.New(mathrand.NewSource(1))
+	bts := make([]byte, 16)
+	for i := 0; i < 20000; i++ {
+		a, b := randRat128(rng, bts), randRat128(rng, bts)
+		ra, rb := a.AsBigRat(), b.AsBigRat()
+
+		sum, sumOK := a.Add(b)
+		diff, diffOK := a.Sub(b)
+		prod, prodOK := a.Mul(b)
+		for _, tc := range []struct {
+			name string
+			out  Rational128
+			ok   bool
+			ref  *big.Rat
+		}{
+			{"+", sum, sumOK, new(big.Rat).Add(ra, rb)},
+			{"-", diff, diffOK, new(big.Rat).Sub(ra, rb)},
+			{"*", prod, prodOK, new(big.Rat).Mul(ra, rb)},
+		} {
+			// Inputs are in lowest terms, so results only fail when the
+			// reduced result doesn't fit:
+			require.Equal(t, fits(tc.ref), tc.ok, "%s %s %s", ra, tc.name, rb)
+			if tc.ok {
+				require.Equal(t, tc.ref.String(), tc.out.AsBigRat().String(), "%s %s %s", ra, tc.name, rb)
+				require.Equal(t, tc.ref.Denom().BitLen(), tc.out.denominator.AbsUint128().BitLen(), "%s %s %s not reduced", ra, tc.name, rb)
+			}
+		}
+
+		if b.sign != 0 {
+			out, ok := a.Div(b)
+			ref := new(big.Rat).Quo(ra, rb)
+			require.Equal(t, fits(ref), ok, "%s / %s", ra, rb)
+			if ok {
+				require.Equal(t, ref.String(), out.AsBigRat().String(), "%s / %s", ra, rb)
+			}
+		}
+	}
+}
@@ -32,6 +32,21 @@ type Rational64 struct {
 	sign        int
 }
 
+// AsRational128 widens r to a Rational128, which always represents it exactly.
+// The result is marked as an int64 if it is a whole number in the range of an
+// Int64, as Rational128FromInt64 would.
+func (r Rational64) AsRational128() Rational128 {
+	out := Rational128{
+		numerator:   Int128{lo: r.numerator},
+		denominator: Int128{lo: r.denominator},
+		sign:        r.sign,
+	}
+	if r.denominator == 1 {
+		out.isInt64 = r.numerator <= maxInt64 || (r.sign < 0 && r.numerator == maxInt64+1)
+	}
+	return out
+}
+
 func (r Rational64) IsNegativeInfinity() bool {
 	return (r.sign < 0) && (r.denominator == 0)
 }
@@ -44,6 +59,209 @@ func (r Rational64) ToScalar() Scalar {
 	if r.denominator == 0 {
 		return Scalar(float64(r.sign) * Infinity)
 	} else {
-		return Scalar(r.sign) * Scalar(r.numerator / r.denominator)
+		return Scalar(r.sign) * Scalar(r.numerator) / Scalar(r.denominator)
+	}
+}
+
+// Reduce returns r in lowest terms, with the numerator and denominator divided
+// by their greatest common divisor. Zero is always reduced to 0/1. A zero
+// denominator is left untouched, as it marks an infinity or NaN.
+func (r Rational64) Reduce() Rational64 {
+	if r.denominator == 0 {
+		return r
+	}
+	if r.isZero() {
+		return rational64Zero()
+	}
+	g := gcd64(r.numerator, r.denominator)
+	r.numerator, r.denominator = r.numerator/g, r.denominator/g
+	return r
+}
+
+// Cmp compares r and o exactly, returning -1 if r < o, 0 if r == o and +1 if
+// r > o. The cross products are formed in a Uint128, so they can't overflow.
+// Infinities compare equal to infinities of the same sign and above or below
+// everything else, and NaN compares equal to everything.
+func (r Rational64) Cmp(o Rational64) int {
+	if r.IsNaN() || o.IsNaN() {
+		return 0
+	}
+
+	rs, os := r.cmpSign(), o.cmpSign()
+	if rs < os {
+		return -1
+	} else if rs > os {
+		return 1
+	} else if rs != 1 && rs != -1 {
+		return 0 // Both zero, or infinities of the same sign
+	}
+	lhs := Uint128From64(r.numerator).Mul64(o.denominator)
+	rhs := Uint128From64(o.numerator).Mul64(r.denominator)
+	return rs * lhs.Cmp(rhs)
+}
+
+// cmpSign returns the sign of r for Cmp, with infinities at +/-2 so they sort
+// outside the finite values.
+func (r Rational64) cmpSign() int {
+	switch {
+	case r.isInf():
+		return 2 * r.sign
+	case r.isZero():
+		return 0
+	}
+	return r.sign
+}
+
+// Add returns r + n. The arithmetic is exact, but ok is false if the numerator
+// or denominator of the result doesn't fit in a Uint64, in which case out is
+// meaningless.
+//
+// NaN + n and Inf + -Inf are NaN, and Inf + n is Inf for any other n.
+//
+// As with Rational128.Add, only the factors common to the denominators are
+// divided out up front, so the cross products fit in a Uint128. If r and n
+// are both in lowest terms, so is the result.
+func (r Rational64) Add(n Rational64) (out Rational64, ok bool) {
+	switch {
+	case r.IsNaN() || n.IsNaN():
+		return Rational64{}, true
+	case r.isInf():
+		if n.isInf() && n.sign != r.sign {
+			return Rational64{}, true
+		}
+		return rational64Inf(r.sign), true
+	case n.isInf():
+		return rational64Inf(n.sign), true
+	case r.isZero():
+		return rational64FromUint128s(n.sign, Uint128From64(n.numerator), Uint128From64(n.denominator))
+	case n.isZero():
+		return rational64FromUint128s(r.sign, Uint128From64(r.numerator), Uint128From64(r.denominator))
+	}
+
+	g := gcd64(r.denominator, n.denominator)
+	rdeng, ndeng := r.denominator/g, n.denominator/g
+
+	t1 := Uint128From64(r.numerator).Mul64(ndeng)
+	t2 := Uint128From64(n.numerator).Mul64(rdeng)
+	var t Uint128
+	sign := r.sign
+	if r.sign == n.sign {
+		var overflow bool
+		if t, overflow = t1.AddOverflow(t2); overflow {
+			return Rational64{}, false
+		}
+	} else {
+		switch t1.Cmp(t2) {
+		case 0:
+			return rational64Zero(), true
+		case -1:
+			t1, t2 = t2, t1
+			sign = n.sign
+		}
+		t = t1.Sub(t2)
+	}
+
+	// Any factor common to t and the new denominator rdeng*nden must divide g:
+	g2 := gcd64(t.Rem64(g).lo, g)
+	return rational64FromUint128s(sign, t.Quo64(g2), Uint128From64(rdeng).Mul64(n.denominator/g2))
+}
+
+// Sub returns r - n, with the same rules as Add.
+func (r Rational64) Sub(n Rational64) (out Rational64, ok bool) {
+	n.sign = -n.sign
+	return r.Add(n)
+}
+
+// Mul returns r * n. The arithmetic is exact, but ok is false if the numerator
+// or denominator of the result doesn't fit in a Uint64, in which case out is
+// meaningless.
+//
+// NaN * n and Inf * 0 are NaN, and Inf * n is Inf with the product of the
+// signs for any other n. If r and n are both in lowest terms, so is the
+// result.
+func (r Rational64) Mul(n Rational64) (out Rational64, ok bool) {
+	switch {
+	case r.IsNaN() || n.IsNaN():
+		return Rational64{}, true
+	case r.isInf() || n.isInf():
+		if r.isZero() || n.isZero() {
+			return Rational64{}, true
+		}
+		return rational64Inf(r.sign * n.sign), true
+	case r.isZero() || n.isZero():
+		return rational64Zero(), true
+	}
+
+	g1, g2 := gcd64(r.numerator, n.denominator), gcd64(n.numerator, r.denominator)
+	num := Uint128From64(r.numerator / g1).Mul64(n.numerator / g2)
+	den := Uint128From64(r.denominator / g2).Mul64(n.denominator / g1)
+	return rational64FromUint128s(r.sign*n.sign, num, den)
+}
+
+// Div returns r / n, with the same rules and precision as Mul. Dividing a
+// non-zero r by zero gives Inf with the sign of r; 0/0 and Inf/Inf are NaN.
+func (r Rational64) Div(n Rational64) (out Rational64, ok bool) {
+	switch {
+	case r.IsNaN() || n.IsNaN():
+		return Rational64{}, true
+	case n.isZero():
+		if r.isZero() {
+			return Rational64{}, true
+		}
+		return rational64Inf(r.sign), true
+	case n.isInf():
+		if r.isInf() {
+			return Rational64{}, true
+		}
+		return rational64Zero(), true
+	}
+
+	n.numerator, n.denominator = n.denominator, n.numerator
+	return r.Mul(n)
+}
+
+func (r Rational64) isInf() bool { return (r.sign != 0) && (r.denominator == 0) }
+
+func (r Rational64) isZero() bool {
+	return (r.denominator != 0) && (r.sign == 0 || r.numerator == 0)
+}
+
+func rational64Zero() Rational64 { return Rational64{denominator: 1} }
+
+func rational64Inf(sign int) Rational64 { return Rational64{sign: sign, numerator: 1} }
+
+// rational64FromUint128s builds a Rational64 from a sign and the magnitudes of
+// its numerator and denominator, with ok set to false if either doesn't fit
+// in a Uint64.
+func rational64FromUint128s(sign int, num, den Uint128) (r Rational64, ok bool) {
+	if !num.IsUint64() || !den.IsUint64() {
+		return r, false
+	}
+	if num.IsZero() {
+		return rational64Zero(), true
+	}
+	return Rational64{sign: sign, numerator: num.lo, denominator: den.lo}, true
+}
+
+// gcd64 returns the greatest common divisor of u and v using the binary
+// algorithm, as in Uint128.GCD. gcd64(0, v) == v.
+func gcd64(u, v Uint64) Uint64 {
+	if u == 0 {
+		return v
+	} else if v == 0 {
+		return u
+	}
+
+	shift := TrailingZeros64(u | v)
+	u >>= TrailingZeros64(u)
+	for {
+		v >>= TrailingZeros64(v)
+		if u > v {
+			u, v = v, u
+		}
+		v -= u
+		if v == 0 {
+			return u << shift
+		}
 	}
 }
@@ -0,0 +1,266 @@
+package geometry
+
+import (
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func rat64(sign int, num, den Uint64) Rational64 {
+	return Rational64{numerator: num, denominator: den, sign: sign}
+}
+
+func rat64Big(r Rational64) *big.Rat {
+	ref := new(big.Rat).SetFrac(new(big.Int).SetUint64(uint64(r.numerator)), new(big.Int).SetUint64(uint64(r.denominator)))
+	if r.sign < 0 {
+		ref.Neg(ref)
+	} else if r.sign == 0 {
+		ref.SetInt64(0)
+	}
+	return ref
+}
+
+// randRat64 returns a random finite Rational64 in lowest terms, with
+// magnitudes of up to 64 bits.
+func randRat64(rng *mathrand.Rand) Rational64 {
+	for {
+		num := Uint64(rng.Uint64()) >> rng.Intn(64)
+		den := Uint64(rng.Uint64()) >> rng.Intn(64)
+		if den == 0 {
+			continue
+		}
+		g := gcd64(num, den)
+		sign := 1 - 2*rng.Intn(2)
+		if num == 0 {
+			return rat64(0, 0, 1)
+		}
+		return rat64(sign, num/g, den/g)
+	}
+}
+
+func TestGCD64(t *testing.T) {
+	for idx, tc := range []struct {
+		u, v, gcd Uint64
+	}{
+		{0, 0, 0},
+		{0, 5, 5},
+		{5, 0, 5},
+		{12, 18, 6},
+		{17, 5, 1},
+		{1 << 63, 1 << 40, 1 << 40},
+		{maxUint64, maxUint64, maxUint64},
+		{maxUint64, maxUint64 - 1, 1},
+	} {
+		t.Run(fmt.Sprintf("%d/gcd(%d,%d)", idx, tc.u, tc.v), func(t *testing.T) {
+			require.Equal(t, tc.gcd, gcd64(tc.u, tc.v))
+			require.Equal(t, tc.gcd, gcd64(tc.v, tc.u))
+		})
+	}
+}
+
+func TestRational64ToScalar(t *testing.T) {
+	require.Equal(t, Scalar(0.5), Rational64FromInt64s(1, 2).ToScalar())
+	require.Equal(t, Scalar(-0.75), Rational64FromInt64s(3, -4).ToScalar())
+	require.Equal(t, Scalar(0), Rational64FromInt64s(0, 4).ToScalar())
+	require.Equal(t, Scalar(7), Rational64FromInt64s(-7, -1).ToScalar())
+	require.Equal(t, Scalar(Infinity), Rational64FromInt64s(1, 0).ToScalar())
+	require.Equal(t, Scalar(-Infinity), Rational64FromInt64s(-1, 0).ToScalar())
+}
+
+func TestRational64Reduce(t *testing.T) {
+	require.Equal(t, rat64(1, 3, 2), rat64(1, 6, 4).Reduce())
+	require.Equal(t, rat64(-1, 3, 2), Rational64FromInt64s(6, -4).Reduce())
+	require.Equal(t, rat64(1, 1, 1), rat64(1, maxUint64, maxUint64).Reduce())
+	require.Equal(t, rat64(1, 5, 7), rat64(1, 5, 7).Reduce())
+	require.Equal(t, rat64(0, 0, 1), rat64(0, 0, 9).Reduce())
+	require.Equal(t, rat64(1, 5, 0), rat64(1, 5, 0).Reduce())
+	require.Equal(t, rat64(0, 0, 0), rat64(0, 0, 0).Reduce())
+}
+
+func TestRational64Cmp(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b Rational64
+		cmp  int
+	}{
+		{rat64(1, 1, 2), rat64(1, 1, 3), 1},
+		{rat64(1, 1, 3), rat64(1, 1, 2), -1},
+		{rat64(1, 2, 4), rat64(1, 1, 2), 0},
+		{rat64(-1, 1, 2), rat64(-1, 1, 3), -1},
+		{rat64(-1, 1, 2), rat64(1, 1, 3), -1},
+		{rat64(0, 0, 1), rat64(-1, 1, 3), 1},
+		{rat64(0, 0, 1), rat64(0, 0, 5), 0},
+		{rat64(1, maxUint64, maxUint64-1), rat64(1, maxUint64-1, maxUint64-2), -1},
+		{rat64(1, maxUint64, 1), rat64(1, 1, 0), -1},
+		{rat64(-1, maxUint64, 1), rat64(-1, 1, 0), 1},
+		{rat64(1, 1, 0), rat64(1, 1, 0), 0},
+		{rat64(-1, 1, 0), rat64(1, 1, 0), -1},
+		{rat64(0, 0, 0), rat64(1, 1, 0), 0},
+		{rat64(0, 0, 0), rat64(-1, 3, 4), 0},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			require.Equal(t, tc.cmp, tc.a.Cmp(tc.b))
+			require.Equal(t, -tc.cmp, tc.b.Cmp(tc.a))
+		})
+	}
+
+	rng := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, b := randRat64(rng), randRat64(rng)
+		require.Equal(t, rat64Big(a).Cmp(rat64Big(b)), a.Cmp(b), "%s <=> %s", rat64Big(a), rat64Big(b))
+	}
+}
+
+func TestRational64AsRational128(t *testing.T) {
+	for idx, tc := range []struct {
+		r       Rational64
+		isInt64 bool
+	}{
+		{Rational64FromInt64s(3, 4), false},
+		{Rational64FromInt64s(-3, 4), false},
+		{Rational64FromInt64s(-7, 1), true},
+		{Rational64FromInt64s(0, 1), true},
+		{rat64(1, maxUint64, maxUint64-1), false},
+		{rat64(1, maxInt64, 1), true},
+		{rat64(1, maxInt64+1, 1), false},
+		{rat64(-1, maxInt64+1, 1), true},
+		{rat64(1, maxUint64, 1), false},
+		{rat64(1, 1, 0), false},
+		{rat64(-1, 1, 0), false},
+		{rat64(0, 0, 0), false},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			wide := tc.r.AsRational128()
+			require.Equal(t, tc.isInt64, wide.isInt64)
+			require.Equal(t, tc.r.IsNaN(), wide.IsNaN())
+			require.Equal(t, tc.r.IsNegativeInfinity(), wide.IsNegativeInfinity())
+			if tc.r.denominator != 0 {
+				require.Equal(t, rat64Big(tc.r).String(), wide.AsBigRat().String())
+			}
+
+			narrow, ok := wide.AsRational64()
+			require.True(t, ok)
+			require.Equal(t, tc.r, narrow)
+		})
+	}
+
+	for _, r := range []Rational128{
+		NewRational128(MaxInt128, i64(1)),
+		NewRational128(i64(1), MaxInt128),
+		NewRational128(i64(-1), u64(maxUint64).Add64(1).AsInt128()),
+	} {
+		_, ok := r.AsRational64()
+		require.False(t, ok, "%s", r)
+	}
+	narrow, ok := NewRational128(i64(-1), u64(maxUint64).AsInt128()).AsRational64()
+	require.True(t, ok)
+	require.Equal(t, rat64(-1, 1, maxUint64), narrow)
+}
+
+func TestRational64Arith(t *testing.T) {
+	var (
+		nan   = Rational64{}
+		inf   = rat64(1, 1, 0)
+		ninf  = rat64(-1, 1, 0)
+		zero  = rat64(0, 0, 1)
+		half  = rat64(1, 1, 2)
+		nhalf = rat64(-1, 1, 2)
+		third = rat64(1, 1, 3)
+		maxr  = rat64(1, maxUint64, 1)
+		minr  = rat64(1, 1, maxUint64)
+	)
+
+	type op func(a, b Rational64) (Rational64, bool)
+	add, sub, mul, div := Rational64.Add, Rational64.Sub, Rational64.Mul, Rational64.Div
+
+	for idx, tc := range []struct {
+		op   op
+		name string
+		a, b Rational64
+		out  Rational64
+		ok   bool
+	}{
+		{add, "+", half, third, rat64(1, 5, 6), true},
+		{add, "+", half, nhalf, zero, true},
+		{add, "+", nhalf, third, rat64(-1, 1, 6), true},
+		{add, "+", zero, third, third, true},
+		{add, "+", maxr, maxr, Rational64{}, false},
+		{add, "+", minr, minr, rat64(1, 2, maxUint64), true},
+		{sub, "-", third, half, rat64(-1, 1, 6), true},
+		{sub, "-", maxr, maxr, zero, true},
+		{mul, "*", half, third, rat64(1, 1, 6), true},
+		{mul, "*", nhalf, nhalf, rat64(1, 1, 4), true},
+		{mul, "*", maxr, minr, rat64(1, 1, 1), true},
+		{mul, "*", maxr, maxr, Rational64{}, false},
+		{div, "/", half, third, rat64(1, 3, 2), true},
+		{div, "/", zero, half, zero, true},
+		{div, "/", maxr, minr, Rational64{}, false},
+
+		// Zero denominators:
+		{add, "+", inf, half, inf, true},
+		{add, "+", half, ninf, ninf, true},
+		{add, "+", inf, ninf, nan, true},
+		{sub, "-", inf, inf, nan, true},
+		{add, "+", nan, half, nan, true},
+		{mul, "*", inf, nhalf, ninf, true},
+		{mul, "*", inf, zero, nan, true},
+		{mul, "*", half, nan, nan, true},
+		{div, "/", half, zero, inf, true},
+		{div, "/", nhalf, zero, ninf, true},
+		{div, "/", zero, zero, nan, true},
+		{div, "/", half, inf, zero, true},
+		{div, "/", inf, ninf, nan, true},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.name), func(t *testing.T) {
+			out, ok := tc.op(tc.a, tc.b)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, tc.out, out)
+			}
+		})
+	}
+}
+
+func TestRational64ArithRandom(t *testing.T) {
+	maxBig := new(big.Int).SetUint64(uint64(maxUint64))
+	fits := func(x *big.Rat) bool {
+		return x.Num().CmpAbs(maxBig) <= 0 && x.Denom().Cmp(maxBig) <= 0
+	}
+
+	rng := mathrand.New(mathrand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		a, b := randRat64(rng), randRat64(rng)
+		ra, rb := rat64Big(a), rat64Big(b)
+
+		sum, sumOK := a.Add(b)
+		diff, diffOK := a.Sub(b)
+		prod, prodOK := a.Mul(b)
+		for _, tc := range []struct {
+			name string
+			out  Rational64
+			ok   bool
+			ref  *big.Rat
+		}{
+			{"+", sum, sumOK, new(big.Rat).Add(ra, rb)},
+			{"-", diff, diffOK, new(big.Rat).Sub(ra, rb)},
+			{"*", prod, prodOK, new(big.Rat).Mul(ra, rb)},
+		} {
+			require.Equal(t, fits(tc.ref), tc.ok, "%s %s %s", ra, tc.name, rb)
+			if tc.ok {
+				require.Equal(t, tc.ref.String(), rat64Big(tc.out).String(), "%s %s %s", ra, tc.name, rb)
+				require.Equal(t, tc.ref.Denom().Uint64(), uint64(tc.out.denominator), "%s %s %s not reduced", ra, tc.name, rb)
+			}
+		}
+
+		if b.sign != 0 {
+			out, ok := a.Div(b)
+			ref := new(big.Rat).Quo(ra, rb)
+			require.Equal(t, fits(ref), ok, "%s / %s", ra, rb)
+			if ok {
+				require.Equal(t, ref.String(), rat64Big(out).String(), "%s / %s", ra, rb)
+			}
+		}
+	}
+}
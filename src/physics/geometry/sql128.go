@@ -0,0 +1,78 @@
+package geometry
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, so a Uint128 can be passed directly as a
+// query argument. The value is the decimal string, which suits numeric and
+// decimal columns; it doesn't fit in a 64-bit integer column in general.
+func (u Uint128) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// NullUint128 is a Uint128 that may be SQL NULL, following the conventions of
+// sql.NullInt64. It implements sql.Scanner, which Uint128 can't do directly as
+// its Scan method is already taken by fmt.Scanner.
+//
+//	var id NullUint128
+//	err := row.Scan(&id)
+type NullUint128 struct {
+	Uint128 Uint128
+	Valid   bool // Valid is true if Uint128 is not NULL
+}
+
+// Scan implements sql.Scanner. The accepted source types are:
+//
+//   - nil, which sets Valid to false
+//   - []byte, which must be exactly 16 bytes in big-endian order, as written
+//     by Uint128.MarshalBinary
+//   - string, which must be a decimal number in Uint128 range
+//   - int64, which must not be negative
+//
+// Anything else is an error. Note that some drivers return numeric columns as
+// []byte holding decimal text; cast those to text in the query so they arrive
+// as a string.
+func (n *NullUint128) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		n.Uint128, n.Valid = Uint128{}, false
+		return nil
+
+	case []byte:
+		if err := n.Uint128.UnmarshalBinary(v); err != nil {
+			return err
+		}
+
+	case string:
+		u, inRange, err := Uint128FromBase(v, 10)
+		if err != nil {
+			return err
+		} else if !inRange {
+			return fmt.Errorf("num: u128 value %q is not in range", v)
+		}
+		n.Uint128 = u
+
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("num: int64 %d was not in valid Uint128 range", v)
+		}
+		n.Uint128 = Uint128From64(Uint64(v))
+
+	default:
+		return fmt.Errorf("num: cannot scan %T into NullUint128", src)
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, returning nil if n is not Valid and the
+// decimal string otherwise.
+func (n NullUint128) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Uint128.Value()
+}
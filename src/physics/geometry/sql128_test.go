@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullUint128Scan(t *testing.T) {
+	var _ sql.Scanner = &NullUint128{}
+	var _ driver.Valuer = NullUint128{}
+	var _ driver.Valuer = Uint128{}
+
+	be, _ := u128s("0x0102030405060708 090a0b0c0d0e0f10").MarshalBinary()
+
+	for idx, tc := range []struct {
+		src   any
+		out   NullUint128
+		valid bool
+		ok    bool
+	}{
+		{nil, NullUint128{}, false, true},
+		{be, NullUint128{u128s("0x0102030405060708 090a0b0c0d0e0f10"), true}, true, true},
+		{"340282366920938463463374607431768211455", NullUint128{MaxUint128, true}, true, true},
+		{"0", NullUint128{u64(0), true}, true, true},
+		{int64(1234), NullUint128{u64(1234), true}, true, true},
+		{int64(maxInt64), NullUint128{u64(maxInt64), true}, true, true},
+		{int64(-1), NullUint128{}, false, false},
+		{"340282366920938463463374607431768211456", NullUint128{}, false, false},
+		{"0x10", NullUint128{}, false, false}, // decimal only
+		{"-1", NullUint128{}, false, false},
+		{[]byte{1, 2, 3}, NullUint128{}, false, false},
+		{1.5, NullUint128{}, false, false},
+	} {
+		var n NullUint128
+		err := n.Scan(tc.src)
+		require.Equal(t, tc.ok, err == nil, "%d: %v", idx, err)
+		if err == nil {
+			require.Equal(t, tc.out, n, "%d", idx)
+			require.Equal(t, tc.valid, n.Valid, "%d", idx)
+		}
+	}
+
+	// Scanning NULL resets a previously valid value:
+	n := NullUint128{MaxUint128, true}
+	require.NoError(t, n.Scan(nil))
+	require.Equal(t, NullUint128{}, n)
+}
+
+func TestUint128Value(t *testing.T) {
+	v, err := MaxUint128.Value()
+	require.NoError(t, err)
+	require.Equal(t, "340282366920938463463374607431768211455", v)
+	require.True(t, driver.IsValue(v))
+
+	v, err = NullUint128{u64(42), true}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "42", v)
+
+	v, err = NullUint128{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	// What Value produces, Scan accepts:
+	var n NullUint128
+	v, _ = MaxUint128.Value()
+	require.NoError(t, n.Scan(v))
+	require.Equal(t, NullUint128{MaxUint128, true}, n)
+}
@@ -1,12 +1,18 @@
 package geometry
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
+	"math/rand"
 	"strconv"
+	"strings"
 )
 
+// Uint128 is an unsigned 128-bit integer. It is always non-negative, so it has
+// no Abs or Sign; use IsZero where a sign test would otherwise be needed.
 type Uint128 struct {
 	hi, lo Uint64
 }
@@ -39,11 +45,28 @@ func MustUint128FromI64(v int64) (out Uint128) {
 }
 
 // Uint128FromString creates a Uint128 from a string. Overflow truncates to MaxUint128
-// and sets inRange to 'false'. Only decimal strings are currently supported.
+// and sets inRange to 'false'.
+//
+// The base is taken from the string's prefix, as with Go integer literals:
+// "0x" or "0X" is hexadecimal, "0o" or "0O" or a bare leading "0" is octal,
+// "0b" or "0B" is binary, and anything else is decimal. Use Uint128FromBase to
+// force a base instead, e.g. so "010" is read as ten.
+//
+// As in Go source, underscores may separate digits, as in "1_000_000" or
+// "0xffff_ffff", but can't lead, trail or be doubled.
 func Uint128FromString(s string) (out Uint128, inRange bool, err error) {
-	// This deliberately limits the scope of what we accept as input just in case
-	// we decide to hand-roll our own fast decimal-only parser:
-	b, ok := new(big.Int).SetString(s, 10)
+	return Uint128FromBase(s, 0)
+}
+
+// Uint128FromBase creates a Uint128 from a string in the given base, which must
+// be between 2 and 62, or 0 to detect the base from the prefix like
+// Uint128FromString. The digits are interpreted as by big.Int.SetString. Overflow
+// truncates to MaxUint128 and sets inRange to 'false'.
+func Uint128FromBase(s string, base int) (out Uint128, inRange bool, err error) {
+	if base != 0 && (base < 2 || base > big.MaxBase) {
+		return out, false, fmt.Errorf("num: unsupported base %d", base)
+	}
+	b, ok := new(big.Int).SetString(s, base)
 	if !ok {
 		return out, false, fmt.Errorf("num: u128 string %q invalid", s)
 	}
@@ -51,6 +74,97 @@ func Uint128FromString(s string) (out Uint128, inRange bool, err error) {
 	return out, inRange, nil
 }
 
+// parseUint128Decimal parses a string of decimal digits without going through
+// big.Int. Signs, prefixes, underscores and surrounding space are all rejected.
+// Digits are consumed up to 19 at a time, which is the most that always fits in
+// a Uint64, so most inputs need only two or three 128-bit multiplies.
+func parseUint128Decimal(s string) (out Uint128, err error) {
+	if len(s) == 0 {
+		return out, fmt.Errorf("num: u128 string %q invalid", s)
+	}
+	in := s
+	for len(s) > 0 {
+		n := len(s)
+		if n > 19 {
+			n = 19
+		}
+		var chunk Uint64
+		for i := 0; i < n; i++ {
+			c := s[i]
+			if c < '0' || c > '9' {
+				return Uint128{}, fmt.Errorf("num: u128 string %q invalid", in)
+			}
+			chunk = chunk*10 + Uint64(c-'0')
+		}
+
+		var overflow, carry bool
+		out, overflow = out.MulOverflow(pow10Uint128[n])
+		out, carry = out.AddOverflow(Uint128{lo: chunk})
+		if overflow || carry {
+			return Uint128{}, fmt.Errorf("num: u128 string %q out of range", in)
+		}
+		s = s[n:]
+	}
+	return out, nil
+}
+
+// decimalChunkBase is the radix used by Uint128FromDecimalChunks and
+// Uint128.DecimalChunks; 10^18 is the largest power of ten that fits in a
+// signed 64-bit column.
+const decimalChunkBase = 1000000000000000000
+
+// Uint128FromDecimalChunks reconstructs a Uint128 from base-10^18 chunks, most
+// significant chunk first. Each chunk must be < 10^18. If a chunk is out of
+// range or the result overflows, ok is set to false.
+func Uint128FromDecimalChunks(chunks []uint64) (out Uint128, ok bool) {
+	for _, c := range chunks {
+		if c >= decimalChunkBase {
+			return zeroUint128, false
+		}
+		if out.hi > maxUint64/decimalChunkBase {
+			return zeroUint128, false
+		}
+		hi, lo := Mul64(out.lo, decimalChunkBase)
+		hi, carry := Add64(hi, out.hi*decimalChunkBase, 0)
+		if carry != 0 {
+			return zeroUint128, false
+		}
+
+		lo, carry = Add64(lo, Uint64(c), 0)
+		hi, carry = Add64(hi, 0, carry)
+		if carry != 0 {
+			return zeroUint128, false
+		}
+		out = Uint128{hi: hi, lo: lo}
+	}
+	return out, true
+}
+
+// DecimalChunks splits u into base-10^18 chunks, most significant chunk first.
+// It is the inverse of Uint128FromDecimalChunks. Zero is returned as a single
+// zero chunk.
+func (u Uint128) DecimalChunks() []uint64 {
+	if u.hi == 0 && u.lo < decimalChunkBase {
+		return []uint64{uint64(u.lo)}
+	}
+
+	// MaxUint128 is 39 decimal digits, so it fits in 3 chunks:
+	var rev [3]uint64
+	n := 0
+	for !u.IsZero() {
+		var r Uint128
+		u, r = u.QuoRem64(decimalChunkBase)
+		rev[n] = uint64(r.lo)
+		n++
+	}
+
+	out := make([]uint64, n)
+	for i := 0; i < n; i++ {
+		out[i] = rev[n-1-i]
+	}
+	return out
+}
+
 func MustUint128FromString(s string) Uint128 {
 	out, inRange, err := Uint128FromString(s)
 	if err != nil {
@@ -178,22 +292,235 @@ func (u Uint128) IsZero() bool { return u.lo == 0 && u.hi == 0 }
 func (u Uint128) Raw() (hi, lo Uint64) { return u.hi, u.lo }
 
 func (u Uint128) String() string {
-	// FIXME: This is good enough for now, but not forever.
-	if u.lo == 0 && u.hi == 0 {
-		return "0"
+	return u.Text(10)
+}
+
+// Text returns the string representation of u in the given base, which must be
+// between 2 and 36. Lower-case letters 'a' to 'z' are used for digit values 10
+// to 35, and there is no prefix, as with big.Int.Text and strconv.FormatUint.
+// Text panics if base is out of range.
+//
+// Values that fit in 64 bits are formatted directly by strconv. Larger values
+// are peeled off a digit at a time with shifts for power-of-two bases, and
+// otherwise split into at most three 64-bit chunks by dividing by a power of
+// base, so no big.Int is needed either way.
+func (u Uint128) Text(base int) string {
+	if base < 2 || base > 36 {
+		panic(fmt.Errorf("num: illegal Uint128 text base %d", base))
 	}
+	return u.text(base, false)
+}
+
+// text does the work for Uint128.Text and Int128.Text, which formats the
+// magnitude and passes neg to have a '-' written in front of it without
+// another allocation. base must already have been checked.
+func (u Uint128) text(base int, neg bool) string {
 	if u.hi == 0 {
-		return strconv.FormatUint(uint64(u.lo), 10)
+		if !neg {
+			return strconv.FormatUint(uint64(u.lo), base)
+		}
+		var scratch [65]byte
+		return string(strconv.AppendUint(append(scratch[:0], '-'), uint64(u.lo), base))
+	}
+
+	var buf [129]byte // enough for -MaxUint128 in base 2
+	i := len(buf)
+
+	if base&(base-1) == 0 {
+		shift := uint(TrailingZeros64(Uint64(base)))
+		mask := Uint64(base - 1)
+		for !u.IsZero() {
+			i--
+			buf[i] = textDigits[u.lo&mask]
+			u = u.Rsh(shift)
+		}
+		if neg {
+			i--
+			buf[i] = '-'
+		}
+		return string(buf[i:])
+	}
+
+	// chunk is the largest power of base that fits in a Uint64, and digits is
+	// its exponent, i.e. the number of digits in each full chunk. chunk is more
+	// than 2^64/36 > 2^58 for every supported base, so at most two divisions
+	// bring u down to a single Uint64:
+	chunk, digits := Uint64(base), 1
+	for chunk <= maxUint64/Uint64(base) {
+		chunk *= Uint64(base)
+		digits++
+	}
+
+	// strconv has its own fast paths for formatting each chunk, particularly in
+	// base 10:
+	var scratch [64]byte
+	for u.hi != 0 {
+		var r Uint128
+		u, r = u.QuoRem64(chunk)
+		d := strconv.AppendUint(scratch[:0], uint64(r.lo), base)
+		i -= copy(buf[i-len(d):], d)
+		for n := len(d); n < digits; n++ {
+			i--
+			buf[i] = '0'
+		}
+	}
+	d := strconv.AppendUint(scratch[:0], uint64(u.lo), base)
+	i -= copy(buf[i-len(d):], d)
+	if neg {
+		i--
+		buf[i] = '-'
 	}
-	v := u.AsBigInt()
-	return v.String()
+	return string(buf[i:])
+}
+
+// textDigits are the digits used by Uint128.Text, as with strconv.
+const textDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// FormatUint128 returns u formatted in the given base; it is the free-function
+// form of Uint128.Text, named after strconv.FormatUint.
+func FormatUint128(u Uint128, base int) string {
+	return u.Text(base)
 }
 
 func (u Uint128) Format(s fmt.State, c rune) {
+	switch c {
+	case 'v':
+		if s.Flag('+') {
+			s = plusVState{s}
+		}
+	case 'b':
+		if s.Flag('#') {
+			// big.Int ignores '#' for 'b', but native integers print "0b":
+			u.formatPrefixedBinary(s)
+			return
+		}
+	}
+
 	// FIXME: This is good enough for now, but not forever.
 	u.AsBigInt().Format(s, c)
 }
 
+// formatPrefixedBinary writes u for the %#b verb, following the same rules as
+// fmt does for native unsigned integers: precision or the '0' flag pad the
+// digits with zeros (the "0b" prefix doesn't count towards a zero-padded
+// width), '+' and ' ' add a sign, and the result is then space-padded to the
+// width.
+func (u Uint128) formatPrefixedBinary(s fmt.State) {
+	width, hasWidth := s.Width()
+	prec := -1
+	if p, ok := s.Precision(); ok {
+		prec = p
+		if prec == 0 && u.IsZero() {
+			// As with fmt, an explicit zero precision prints nothing at all
+			// for zero, not even the prefix:
+			_, _ = io.WriteString(s, strings.Repeat(" ", width))
+			return
+		}
+	} else if hasWidth && s.Flag('0') && !s.Flag('-') {
+		prec = width
+		if s.Flag('+') || s.Flag(' ') {
+			prec--
+		}
+	}
+
+	var sign string
+	if s.Flag('+') {
+		sign = "+"
+	} else if s.Flag(' ') {
+		sign = " "
+	}
+
+	digits := u.Text(2)
+	var out strings.Builder
+	out.WriteString(sign)
+	out.WriteString("0b")
+	for i := len(digits); i < prec; i++ {
+		out.WriteByte('0')
+	}
+	out.WriteString(digits)
+
+	str := out.String()
+	if hasWidth && width > len(str) {
+		pad := strings.Repeat(" ", width-len(str))
+		if s.Flag('-') {
+			str += pad
+		} else {
+			str = pad + str
+		}
+	}
+	_, _ = io.WriteString(s, str)
+}
+
+// plusVState hides the '+' flag from a fmt.State. fmt sets it for %+v, which
+// asks for struct field names rather than an explicit sign, but big.Int.Format
+// can't tell the difference and would prefix the number with '+'.
+type plusVState struct {
+	fmt.State
+}
+
+func (s plusVState) Flag(c int) bool {
+	if c == '+' {
+		return false
+	}
+	return s.State.Flag(c)
+}
+
+// ScientificString formats u in scientific notation with sigDigits significant
+// digits, like "3.40e+38". The mantissa and exponent are derived from the exact
+// decimal digits of u rather than from a float64, so the exponent is always
+// the decimal digit count minus one (unless rounding carries into a new
+// digit). Ties are rounded away from zero. sigDigits < 1 is treated as 1.
+func (u Uint128) ScientificString(sigDigits int) string {
+	return scientificString(u.String(), sigDigits)
+}
+
+// scientificString formats a string of decimal digits with no sign or leading
+// zeros (other than "0" itself) in scientific notation.
+func scientificString(digits string, sigDigits int) string {
+	if sigDigits < 1 {
+		sigDigits = 1
+	}
+	exp := len(digits) - 1
+
+	mant := make([]byte, sigDigits)
+	for i := range mant {
+		if i < len(digits) {
+			mant[i] = digits[i]
+		} else {
+			mant[i] = '0'
+		}
+	}
+
+	if sigDigits < len(digits) && digits[sigDigits] >= '5' {
+		i := sigDigits - 1
+		for ; i >= 0; i-- {
+			if mant[i] != '9' {
+				mant[i]++
+				break
+			}
+			mant[i] = '0'
+		}
+		if i < 0 {
+			// Carried out of the leading digit, e.g. 9.99 -> 10.0:
+			mant[0] = '1'
+			exp++
+		}
+	}
+
+	out := make([]byte, 0, sigDigits+6)
+	out = append(out, mant[0])
+	if sigDigits > 1 {
+		out = append(out, '.')
+		out = append(out, mant[1:]...)
+	}
+	out = append(out, 'e', '+')
+	if exp < 10 {
+		out = append(out, '0')
+	}
+	out = strconv.AppendInt(out, int64(exp), 10)
+	return string(out)
+}
+
 func (u *Uint128) Scan(state fmt.ScanState, verb rune) error {
 	t, err := state.Token(true, nil)
 	if err != nil {
@@ -214,39 +541,50 @@ func (u *Uint128) Scan(state fmt.ScanState, verb rune) error {
 
 func (u Uint128) IntoBigInt(b *big.Int) {
 	switch intSize {
+	case 64, 32:
+		b.SetBits(putUint128Words(b.Bits(), u.hi, u.lo, intSize))
+
+	default:
+		if u.hi > 0 {
+			b.SetUint64(uint64(u.hi))
+			b.Lsh(b, 64)
+		}
+		var lo big.Int
+		lo.SetUint64(uint64(u.lo))
+		b.Add(b, &lo)
+	}
+}
+
+// putUint128Words writes the 128-bit value hi:lo into bits as little-endian
+// big.Words of wordSize bits, reusing the slice's storage where possible. The
+// word size is passed in rather than read from intSize so the 32-bit layout can
+// be exercised on a 64-bit build.
+func putUint128Words(bits []big.Word, hi, lo Uint64, wordSize Uint64) []big.Word {
+	switch wordSize {
 	case 64:
-		bits := b.Bits()
 		ln := len(bits)
-		if len(bits) < 2 {
+		if ln < 2 {
 			bits = append(bits, make([]big.Word, 2-ln)...)
 		}
 		bits = bits[:2]
-		bits[0] = big.Word(u.lo)
-		bits[1] = big.Word(u.hi)
-		b.SetBits(bits)
+		bits[0] = big.Word(lo)
+		bits[1] = big.Word(hi)
 
 	case 32:
-		bits := b.Bits()
 		ln := len(bits)
-		if len(bits) < 4 {
+		if ln < 4 {
 			bits = append(bits, make([]big.Word, 4-ln)...)
 		}
 		bits = bits[:4]
-		bits[0] = big.Word(u.lo & 0xFFFFFFFF)
-		bits[1] = big.Word(u.lo >> 32)
-		bits[2] = big.Word(u.hi & 0xFFFFFFFF)
-		bits[3] = big.Word(u.hi >> 32)
-		b.SetBits(bits)
+		bits[0] = big.Word(lo & 0xFFFFFFFF)
+		bits[1] = big.Word(lo >> 32)
+		bits[2] = big.Word(hi & 0xFFFFFFFF)
+		bits[3] = big.Word(hi >> 32)
 
 	default:
-		if u.hi > 0 {
-			b.SetUint64(uint64(u.hi))
-			b.Lsh(b, 64)
-		}
-		var lo big.Int
-		lo.SetUint64(uint64(u.lo))
-		b.Add(b, &lo)
+		panic("num: unsupported bit size")
 	}
+	return bits
 }
 
 // AsBigInt returns the Uint128 as a big.Int. This will allocate memory. If
@@ -262,6 +600,17 @@ func (u Uint128) AsBigFloat() (b *big.Float) {
 	return new(big.Float).SetInt(u.AsBigInt())
 }
 
+// AsBigFloatExact returns u as a big.Float with a fixed precision of 128 bits,
+// so the result is exactly u and b.Int(nil) returns u unchanged.
+//
+// AsBigFloat is also exact, but its precision follows the size of u, with a
+// minimum of 64 bits. As big.Float arithmetic rounds to the precision of the
+// receiver, a later operation on that result can silently round it. Using the
+// full 128 bits throughout avoids that.
+func (u Uint128) AsBigFloatExact() (b *big.Float) {
+	return new(big.Float).SetPrec(128).SetInt(u.AsBigInt())
+}
+
 func (u Uint128) AsFloat64() float64 {
 	if u.hi == 0 && u.lo == 0 {
 		return 0
@@ -272,6 +621,21 @@ func (u Uint128) AsFloat64() float64 {
 	}
 }
 
+// ToQFloat interprets u as an unsigned fixed-point number in Q notation with
+// fracBits fractional bits and returns its value, i.e. u / 2^fracBits. See
+// Int128.ToQFloat.
+func (u Uint128) ToQFloat(fracBits int) float64 {
+	return math.Ldexp(u.AsFloat64(), -fracBits)
+}
+
+// Uint128FromQFloat converts f to an unsigned fixed-point Uint128 in Q notation
+// with fracBits fractional bits, i.e. f * 2^fracBits rounded to the nearest
+// integer (halves away from zero). If the result is outside the bounds of a
+// Uint128 or f is NaN, inRange is set to false; see Uint128FromFloat64.
+func Uint128FromQFloat(f float64, fracBits int) (out Uint128, inRange bool) {
+	return Uint128FromFloat64(math.Round(math.Ldexp(f, fracBits)))
+}
+
 // AsInt128 performs a direct cast of a Uint128 to an Int128, which will interpret it
 // as a two's complement value.
 func (u Uint128) AsInt128() Int128 {
@@ -283,6 +647,25 @@ func (u Uint128) IsInt128() bool {
 	return u.hi& int128SignBit == 0
 }
 
+// MustInt128 converts u to an Int128 if the conversion would succeed, and
+// panics if it would not, i.e. if IsInt128 is false.
+func (u Uint128) MustInt128() Int128 {
+	if u.hi&int128SignBit != 0 {
+		panic(fmt.Errorf("Uint128 %v is not representable as an Int128", u))
+	}
+	return Int128{hi: u.hi, lo: u.lo}
+}
+
+// Int128Checked converts u to an Int128, with ok set to false instead of
+// reinterpreting the top bit as the sign if u is too big. See AsInt128 for the
+// unchecked conversion.
+func (u Uint128) Int128Checked() (out Int128, ok bool) {
+	if u.hi&int128SignBit != 0 {
+		return out, false
+	}
+	return Int128{hi: u.hi, lo: u.lo}, true
+}
+
 // AsUint64 truncates the Uint128 to fit in a Uint64. Values outside the range
 // will over/underflow. See IsUint64() if you want to check before you convert.
 func (u Uint128) AsUint64() Uint64 {
@@ -331,6 +714,43 @@ func (u Uint128) Add64(n Uint64) (v Uint128) {
 	return v
 }
 
+// AddOverflow returns u + n and reports whether the addition carried out of
+// the high word. The wrapped result, equal to Add, is returned either way.
+func (u Uint128) AddOverflow(n Uint128) (v Uint128, overflow bool) {
+	var carry Uint64
+	v.lo, carry = Add64(u.lo, n.lo, 0)
+	v.hi, carry = Add64(u.hi, n.hi, carry)
+	return v, carry != 0
+}
+
+// SaturatingAdd returns u + n, clamped to MaxUint128 instead of wrapping.
+func (u Uint128) SaturatingAdd(n Uint128) Uint128 {
+	if v, overflow := u.AddOverflow(n); !overflow {
+		return v
+	}
+	return MaxUint128
+}
+
+// AddN returns u + stride*count, the value after count successive Add64(stride)
+// calls, without looping. As with AddOverflow, if the result overflows,
+// overflow is set to true and the wrapped value is returned.
+func (u Uint128) AddN(stride, count uint64) (v Uint128, overflow bool) {
+	var n Uint128
+	n.hi, n.lo = Mul64(Uint64(stride), Uint64(count)) // can't overflow 128 bits
+
+	var carry Uint64
+	v.lo, carry = Add64(u.lo, n.lo, 0)
+	v.hi, carry = Add64(u.hi, n.hi, carry)
+	return v, carry != 0
+}
+
+// Midpoint returns (u+n)/2 rounded down, without the intermediate sum
+// overflowing. The bits u and n share are kept as-is and the bits that differ
+// are halved, so nothing ever needs more than 128 bits.
+func (u Uint128) Midpoint(n Uint128) Uint128 {
+	return u.And(n).Add(u.Xor(n).Rsh(1))
+}
+
 func (u Uint128) Sub(n Uint128) (v Uint128) {
 	var borrowed Uint64
 	v.lo, borrowed = Sub64(u.lo, n.lo, 0)
@@ -338,6 +758,24 @@ func (u Uint128) Sub(n Uint128) (v Uint128) {
 	return v
 }
 
+// SubOverflow returns u - n and reports whether the subtraction went below
+// zero, which is true if and only if n > u. The wrapped result, equal to Sub, is
+// returned either way.
+func (u Uint128) SubOverflow(n Uint128) (v Uint128, overflow bool) {
+	var borrowed Uint64
+	v.lo, borrowed = Sub64(u.lo, n.lo, 0)
+	v.hi, borrowed = Sub64(u.hi, n.hi, borrowed)
+	return v, borrowed != 0
+}
+
+// SaturatingSub returns u - n, clamped to 0 instead of wrapping.
+func (u Uint128) SaturatingSub(n Uint128) Uint128 {
+	if v, overflow := u.SubOverflow(n); !overflow {
+		return v
+	}
+	return Uint128{}
+}
+
 func (u Uint128) Sub64(n Uint64) (v Uint128) {
 	var borrowed Uint64
 	v.lo, borrowed = Sub64(u.lo, n, 0)
@@ -355,6 +793,12 @@ func (u Uint128) Sub64(n Uint64) (v Uint128) {
 // satisfy the above constraints.
 //
 func (u Uint128) Cmp(n Uint128) int {
+	// Operands that both fit in 64 bits take the u.hi == n.hi branch, so they
+	// already cost a single 64-bit compare after the hi check. A separate
+	// u.hi|n.hi == 0 fast path was tried here and in LessThan/GreaterThan. Cmp
+	// and GreaterThan didn't gain anything on the "both64" benchmark cases.
+	// LessThan was about 30% faster on both64, but up to 27% slower with
+	// 128-bit operands, so none of them have it.
 	if u.hi == n.hi {
 		if u.lo > n.lo {
 			return 1
@@ -420,6 +864,26 @@ func (u Uint128) LessOrEqualTo64(n Uint64) bool {
 	return u.hi == 0 && u.lo <= n
 }
 
+// Between reports whether lo <= u <= hi. The bounds are inclusive; if lo > hi,
+// Between always returns false.
+func (u Uint128) Between(lo, hi Uint128) bool {
+	return u.Cmp(lo) >= 0 && u.Cmp(hi) <= 0
+}
+
+// Clamp returns lo if u < lo, hi if u > hi, and u otherwise. Clamp panics if
+// lo > hi, as there is no value that satisfies both bounds.
+func (u Uint128) Clamp(lo, hi Uint128) Uint128 {
+	if lo.Cmp(hi) > 0 {
+		panic(fmt.Errorf("num: invalid Uint128 clamp range [%s, %s]", lo, hi))
+	}
+	if u.Cmp(lo) < 0 {
+		return lo
+	} else if u.Cmp(hi) > 0 {
+		return hi
+	}
+	return u
+}
+
 func (u Uint128) And(n Uint128) Uint128 {
 	u.hi = u.hi & n.hi
 	u.lo = u.lo & n.lo
@@ -473,12 +937,31 @@ func (u Uint128) BitLen() int {
 	return Len64(u.lo)
 }
 
+// Len is an alias for BitLen, named after math/bits.Len.
+func (u Uint128) Len() int { return u.BitLen() }
+
+// Log2 returns floor(log2(u)), which is BitLen()-1. Log2 of 0 is undefined, so
+// -1 is returned rather than panicking.
+func (u Uint128) Log2() int { return u.BitLen() - 1 }
+
+// Log10 returns floor(log10(u)), one less than the number of decimal digits in
+// u. As with Log2, Log10 of 0 returns -1.
+func (u Uint128) Log10() int {
+	if u.IsZero() {
+		return -1
+	}
+	// 1233/4096 is a close underestimate of log10(2), so t is either the
+	// answer or one too high:
+	t := u.BitLen() * 1233 >> 12
+	if u.LessThan(pow10Uint128[t]) {
+		return t - 1
+	}
+	return t
+}
+
 // OnesCount returns the number of one bits ("population count") in u.
 func (u Uint128) OnesCount() int {
-	if u.hi > 0 {
-		return OnesCount64(u.hi) + 64
-	}
-	return OnesCount64(u.lo)
+	return OnesCount64(u.hi) + OnesCount64(u.lo)
 }
 
 // Bit returns the value of the i'th bit of x. That is, it returns (x>>i)&1.
@@ -557,12 +1040,141 @@ func (u Uint128) Mul(n Uint128) Uint128 {
 	return Uint128{hi, lo}
 }
 
+// MulOverflow returns u * n and reports whether the true product needs more
+// than 128 bits. The wrapped result, equal to Mul, is returned either way.
+func (u Uint128) MulOverflow(n Uint128) (v Uint128, overflow bool) {
+	hi, lo := u.MulFull(n)
+	return lo, !hi.IsZero()
+}
+
+// SaturatingMul returns u * n, clamped to MaxUint128 instead of wrapping.
+func (u Uint128) SaturatingMul(n Uint128) Uint128 {
+	if v, overflow := u.MulOverflow(n); !overflow {
+		return v
+	}
+	return MaxUint128
+}
+
 func (u Uint128) Mul64(n Uint64) (dest Uint128) {
 	dest.hi, dest.lo = Mul64(u.lo, n)
 	dest.hi += u.hi * n
 	return dest
 }
 
+// MulDiv returns u*mul/div, computed from the full 256-bit product so that
+// the multiplication can't overflow before the division. If div == 0, a
+// division-by-zero run-time panic occurs.
+//
+// If the quotient itself doesn't fit in 128 bits, which happens when the high
+// 128 bits of u*mul are >= div, the result wraps like Mul.
+func (u Uint128) MulDiv(mul, div Uint128) Uint128 {
+	if div.lo == 0 && div.hi == 0 {
+		panic("u128: division by zero")
+	}
+
+	hi, lo := u.MulFull(mul)
+	if !hi.LessThan(div) {
+		// The bits of the quotient above 2^128 are discarded, but the
+		// remainder of the high half carries into the low half:
+		_, hi = hi.QuoRem(div)
+	}
+	q, _ := quoRem256by128(hi, lo, div)
+	return q
+}
+
+// FastRangeN maps u into [0, n) without a modulo, using Lemire's "fastrange"
+// reduction extended to 128 bits: it returns the high 64 bits of the 192-bit
+// product u * n, i.e. (u * n) >> 128.
+//
+// Unlike u % n, the result depends on the high bits of u, so it is suitable
+// for indexing into tables with hashes whose low bits are weak. It is uniform
+// for uniformly distributed u. If n == 0, the result is 0.
+func (u Uint128) FastRangeN(n uint64) uint64 {
+	hiHi, hiLo := Mul64(u.hi, Uint64(n))
+	loHi, _ := Mul64(u.lo, Uint64(n))
+	_, carry := Add64(hiLo, loHi, 0)
+	return uint64(hiHi + carry)
+}
+
+// ClMul returns the carryless (XOR) product of u and n, treating both as
+// polynomials over GF(2) where bit i is the coefficient of x^i. The product has
+// degree at most 254, so it is returned as a 256-bit value split into hi and lo.
+//
+// This is the multiplication used by GHASH and CRCs; it is unrelated to the
+// arithmetic product returned by Mul.
+func (u Uint128) ClMul(n Uint128) (hi, lo Uint128) {
+	// Schoolbook over 64-bit limbs, with the middle terms landing on bits 64-191:
+	lo.hi, lo.lo = clMul64(u.lo, n.lo)
+	hi.hi, hi.lo = clMul64(u.hi, n.hi)
+
+	m1hi, m1lo := clMul64(u.hi, n.lo)
+	m2hi, m2lo := clMul64(u.lo, n.hi)
+	lo.hi ^= m1lo ^ m2lo
+	hi.lo ^= m1hi ^ m2hi
+	return hi, lo
+}
+
+// ReduceGHASH reduces the 256-bit polynomial hi<<128 | lo, as returned by ClMul,
+// modulo the GHASH field polynomial x^128 + x^7 + x^2 + x + 1.
+//
+// Like ClMul, bit i is the coefficient of x^i. GCM stores field elements
+// bit-reflected (the most significant bit of the first byte is x^0), so GCM
+// blocks must have their bits reversed before and after using this.
+func ReduceGHASH(hi, lo Uint128) Uint128 {
+	// x^128 == x^7 + x^2 + x + 1, so fold hi down once; the bits that spill
+	// past x^127 while doing so have degree < 7 and need one more fold:
+	spill := hi.Rsh(127).Xor(hi.Rsh(126)).Xor(hi.Rsh(121))
+	lo = lo.Xor(hi).Xor(hi.Lsh(1)).Xor(hi.Lsh(2)).Xor(hi.Lsh(7))
+	return lo.Xor(spill).Xor(spill.Lsh(1)).Xor(spill.Lsh(2)).Xor(spill.Lsh(7))
+}
+
+// GFMul returns the product of u and n in GF(2^128) as defined for GHASH,
+// i.e. ReduceGHASH(u.ClMul(n)). See ReduceGHASH for the bit order.
+func (u Uint128) GFMul(n Uint128) Uint128 {
+	return ReduceGHASH(u.ClMul(n))
+}
+
+// MulFull returns the full 256-bit product of u and n as hi<<128 | lo. Unlike
+// Mul, the high 128 bits are kept rather than discarded, so u*n overflowed
+// Uint128 if and only if !hi.IsZero().
+func (u Uint128) MulFull(n Uint128) (hi, lo Uint128) {
+	hi00, lo00 := Mul64(u.lo, n.lo)
+	hi01, lo01 := Mul64(u.lo, n.hi)
+	hi10, lo10 := Mul64(u.hi, n.lo)
+	hi11, lo11 := Mul64(u.hi, n.hi)
+
+	var c1, c2, c Uint64
+	lo.lo = lo00
+	lo.hi, c1 = Add64(hi00, lo01, 0)
+	lo.hi, c = Add64(lo.hi, lo10, 0)
+	c1 += c
+
+	hi.lo, c2 = Add64(hi01, hi10, 0)
+	hi.lo, c = Add64(hi.lo, lo11, 0)
+	c2 += c
+	hi.lo, c = Add64(hi.lo, c1, 0)
+	c2 += c
+
+	// The product of two 128-bit values always fits in 256 bits, so this can't
+	// carry out:
+	hi.hi = hi11 + c2
+	return hi, lo
+}
+
+// clMul64 returns the 128-bit carryless product of x and y using the portable
+// shift-and-xor loop; no hardware CLMUL instruction is assumed.
+func clMul64(x, y Uint64) (hi, lo Uint64) {
+	for y != 0 {
+		i := uint(TrailingZeros64(y))
+		lo ^= x << i
+		if i > 0 {
+			hi ^= x >> (64 - i)
+		}
+		y &= y - 1
+	}
+	return hi, lo
+}
+
 // See BenchmarkUint128QuoRemTZ for the test that helps determine this magic number:
 const divAlgoLeading0Spill = 16
 
@@ -645,25 +1257,89 @@ func (u Uint128) QuoRem(by Uint128) (q, r Uint128) {
 		return q, r
 	}
 
-	var byLoLeading0, byHiLeading0, byLeading0 uint
+	return NewUint128Divisor(by).quoRem(u)
+}
+
+func (u Uint128) QuoRem64(by Uint64) (q, r Uint128) {
+	if u.hi < by {
+		q.lo, r.lo = Div64(u.hi, u.lo, by)
+	} else {
+		q.hi, r.lo = Div64(0, u.hi, by)
+		q.lo, r.lo = Div64(r.lo, u.lo, by)
+	}
+	return q, r
+}
+
+// QuoRemPow2 returns the quotient and remainder of u divided by 2^log2, using
+// only a shift and a mask. If log2 >= 128, QuoRemPow2 will panic.
+func (u Uint128) QuoRemPow2(log2 uint) (q, r Uint128) {
+	if log2 >= 128 {
+		panic("u128: power of two divisor out of range")
+	}
+	q = u.Rsh(log2)
+	if log2 >= 64 {
+		r.hi = u.hi & (1<<(log2-64) - 1)
+		r.lo = u.lo
+	} else {
+		r.lo = u.lo & (1<<log2 - 1)
+	}
+	return q, r
+}
+
+// Uint128Divisor caches the analysis of a divisor that Uint128.QuoRem would
+// otherwise redo on every call, for loops that divide many dividends by the
+// same value. Use NewUint128Divisor to create one.
+type Uint128Divisor struct {
+	by                                     Uint128
+	byHiLeading0, byLoLeading0, byLeading0 uint
+	byTrailing0                            uint
+	pow2                                   bool
+}
+
+// NewUint128Divisor prepares by for repeated division. If by == 0, a
+// division-by-zero run-time panic occurs.
+func NewUint128Divisor(by Uint128) (d Uint128Divisor) {
+	if by.lo == 0 && by.hi == 0 {
+		panic("u128: division by zero")
+	}
+
+	d.by = by
 	if by.hi == 0 {
-		byLoLeading0, byHiLeading0 = uint(LeadingZeros64(by.lo)), 64
-		byLeading0 = byLoLeading0 + 64
+		d.byLoLeading0, d.byHiLeading0 = uint(LeadingZeros64(by.lo)), 64
+		d.byLeading0 = d.byLoLeading0 + 64
 	} else {
-		byHiLeading0 = uint(LeadingZeros64(by.hi))
-		byLeading0 = byHiLeading0
+		d.byHiLeading0 = uint(LeadingZeros64(by.hi))
+		d.byLeading0 = d.byHiLeading0
 	}
+	d.byTrailing0 = by.TrailingZeros()
+	d.pow2 = (d.byLeading0 + d.byTrailing0) == 127
+	return d
+}
 
-	if byLeading0 == 127 {
-		return u, r
+// Divisor returns the value d divides by.
+func (d Uint128Divisor) Divisor() Uint128 { return d.by }
+
+// QuoRem returns the quotient and remainder of u divided by the divisor. The
+// result is identical to u.QuoRem(d.Divisor()).
+func (d Uint128Divisor) QuoRem(u Uint128) (q, r Uint128) {
+	if u.hi|d.by.hi == 0 {
+		// protected from div/0 because by.lo is guaranteed to be set if by.hi is 0:
+		q.lo = u.lo / d.by.lo
+		r.lo = u.lo % d.by.lo
+		return q, r
 	}
+	return d.quoRem(u)
+}
 
-	byTrailing0 := by.TrailingZeros()
-	if (byLeading0 + byTrailing0) == 127 {
-		q = u.Rsh(byTrailing0)
-		by = by.Dec()
-		r = by.And(u)
-		return
+// quoRem is the part of QuoRem shared with Uint128.QuoRem, which skips the
+// divisor analysis when u and the divisor both fit in 64 bits.
+func (d Uint128Divisor) quoRem(u Uint128) (q, r Uint128) {
+	by := d.by
+	if d.pow2 {
+		// Includes by == 1, where byTrailing0 == 0:
+		q = u.Rsh(d.byTrailing0)
+		r = by.Dec().And(u)
+		return q, r
 	}
 
 	if cmp := u.Cmp(by); cmp < 0 {
@@ -675,23 +1351,13 @@ func (u Uint128) QuoRem(by Uint128) (q, r Uint128) {
 	}
 
 	uLeading0 := u.LeadingZeros()
-	if byLeading0-uLeading0 > divAlgoLeading0Spill {
-		return quorem128by128(u, by, byHiLeading0, byLoLeading0)
+	if d.byLeading0-uLeading0 > divAlgoLeading0Spill {
+		return quorem128by128(u, by, d.byHiLeading0, d.byLoLeading0)
 	} else {
-		return quorem128bin(u, by, uLeading0, byLeading0)
+		return quorem128bin(u, by, uLeading0, d.byLeading0)
 	}
 }
 
-func (u Uint128) QuoRem64(by Uint64) (q, r Uint128) {
-	if u.hi < by {
-		q.lo, r.lo = Div64(u.hi, u.lo, by)
-	} else {
-		q.hi, r.lo = Div64(0, u.hi, by)
-		q.lo, r.lo = Div64(r.lo, u.lo, by)
-	}
-	return q, r
-}
-
 // Rem returns the remainder of x%y for y != 0. If y == 0, a division-by-zero
 // run-time panic occurs. Rem implements truncated modulus (like Go); see
 // QuoRem for more details.
@@ -720,6 +1386,148 @@ func (u Uint128) Rem64(by Uint64) (r Uint128) {
 	return r
 }
 
+// Sqrt returns floor(sqrt(u)), i.e. the largest r such that r*r <= u. It uses
+// Newton's method, starting from a power of two that is known to be >= the
+// root so the iterates decrease monotonically; no intermediate value exceeds
+// 2^65, so it is exact all the way up to MaxUint128.
+func (u Uint128) Sqrt() Uint128 {
+	if u.hi == 0 && u.lo < 2 {
+		return u
+	}
+
+	x := Uint128{lo: 1}.Lsh(uint(u.BitLen()+1) / 2)
+	for {
+		y := x.Add(u.Quo(x)).Rsh(1)
+		if !y.LessThan(x) {
+			return x
+		}
+		x = y
+	}
+}
+
+// GCD returns the greatest common divisor of u and v using the binary (Stein's)
+// algorithm, which needs only shifts and subtraction. GCD(0, v) == v and
+// GCD(0, 0) == 0.
+func (u Uint128) GCD(v Uint128) Uint128 {
+	if u.IsZero() {
+		return v
+	} else if v.IsZero() {
+		return u
+	}
+
+	// The common factors of two are put back at the end:
+	shift := u.Or(v).TrailingZeros()
+	u = u.Rsh(u.TrailingZeros())
+	for {
+		// u is odd here, so any factors of two in v aren't common:
+		v = v.Rsh(v.TrailingZeros())
+		if u.Cmp(v) > 0 {
+			u, v = v, u
+		}
+		v = v.Sub(u)
+		if v.IsZero() {
+			return u.Lsh(shift)
+		}
+	}
+}
+
+// ModInverse returns the x such that u*x % mod == 1, with 0 <= x < mod. If u and
+// mod aren't coprime there is no inverse, and ok is false. If mod == 0, a
+// division-by-zero run-time panic occurs.
+//
+// This is the extended Euclidean algorithm rather than the binary one, which
+// only works for odd moduli. The Bezout coefficients alternate in sign and
+// their magnitudes never exceed mod, so they are tracked as unsigned
+// magnitudes and the sign is recovered from the number of steps.
+func (u Uint128) ModInverse(mod Uint128) (x Uint128, ok bool) {
+	if mod.lo == 0 && mod.hi == 0 {
+		panic("u128: division by zero")
+	}
+
+	r0, r1 := mod, u.Rem(mod)
+	t0, t1 := Uint128{}, Uint128{lo: 1}
+	steps := 0
+	for !r1.IsZero() {
+		q, r := r0.QuoRem(r1)
+		r0, r1 = r1, r
+		t0, t1 = t1, t0.Add(q.Mul(t1))
+		steps++
+	}
+
+	if r0.hi != 0 || r0.lo != 1 {
+		return zeroUint128, false
+	}
+	if steps%2 == 0 && !t0.IsZero() {
+		t0 = mod.Sub(t0) // t0 is negative
+	}
+	return t0, true
+}
+
+// Pow returns u**exp, wrapping on overflow like Go's native integers.
+func (u Uint128) Pow(exp uint) Uint128 {
+	out := Uint128{lo: 1}
+	for ; exp != 0; exp >>= 1 {
+		if exp&1 != 0 {
+			out = out.Mul(u)
+		}
+		u = u.Mul(u)
+	}
+	return out
+}
+
+// PowMod returns u**exp % mod without overflowing. If mod == 0, a
+// division-by-zero run-time panic occurs. 0**0 % mod is 1 % mod, as in big.Int.Exp.
+func (u Uint128) PowMod(exp, mod Uint128) Uint128 {
+	if mod.lo == 0 && mod.hi == 0 {
+		panic("u128: division by zero")
+	}
+
+	out := Uint128{lo: 1}.Rem(mod)
+	u = u.Rem(mod)
+	for ; !exp.IsZero(); exp = exp.Rsh(1) {
+		if exp.lo&1 != 0 {
+			out = mulMod(out, u, mod)
+		}
+		u = mulMod(u, u, mod)
+	}
+	return out
+}
+
+// mulMod returns a*b % m for a, b < m. The product needs up to 256 bits, so
+// unless m fits in 64 bits, it is reduced from the full-width product.
+func mulMod(a, b, m Uint128) Uint128 {
+	if m.hi == 0 {
+		return a.Mul(b).Rem64(m.lo) // a, b < 2^64, so the product fits
+	}
+	hi, lo := a.MulFull(b)
+	_, r := quoRem256by128(hi, lo, m)
+	return r
+}
+
+// quoRem256by128 returns the quotient and remainder of (hi<<128 | lo) / m for
+// hi < m, which guarantees the quotient fits in 128 bits. It uses binary long
+// division over the bits of lo.
+func quoRem256by128(hi, lo, m Uint128) (q, r Uint128) {
+	if hi.IsZero() {
+		return lo.QuoRem(m)
+	}
+
+	r = hi
+	for i := 127; i >= 0; i-- {
+		// r < m, so if shifting r left carries out of bit 127 the true value
+		// is >= 2^128 > m, and subtracting m with wraparound gives the right
+		// answer:
+		carry := r.hi >> 63
+		r = r.Lsh(1).Or64(Uint64(lo.Bit(i)))
+		q = q.Lsh(1)
+		if carry != 0 || !r.LessThan(m) {
+			r = r.Sub(m)
+			q.lo |= 1
+		}
+	}
+	return q, r
+}
+
 func (u Uint128) Reverse() Uint128 {
 	return Uint128{hi: Reverse64(u.lo), lo: Reverse64(u.hi)}
 }
@@ -747,6 +1555,79 @@ func (u Uint128) RotateLeft(k int) Uint128 {
 	}
 }
 
+// FunnelShiftLeft shifts the 256-bit value hi<<128 | lo left by n and returns
+// the upper 128 bits, i.e. bits [128-n, 256-n) of hi<<128 | lo: n == 0 gives
+// hi and n == 128 gives lo. The window [n, n+128) is FunnelShiftRight. n must
+// be in [0, 128], otherwise FunnelShiftLeft will panic.
+// FunnelShiftLeft(u, u, n) == u.RotateLeft(int(n)).
+func FunnelShiftLeft(hi, lo Uint128, n uint) Uint128 {
+	switch {
+	case n == 0:
+		return hi
+	case n < 128:
+		return hi.Lsh(n).Or(lo.Rsh(128 - n))
+	case n == 128:
+		return lo
+	default:
+		panic("u128: funnel shift out of range")
+	}
+}
+
+// FunnelShiftRight shifts the 256-bit value hi<<128 | lo right by n and returns
+// the lower 128 bits, i.e. bits [n, n+128) of hi<<128 | lo: n == 0 gives lo
+// and n == 128 gives hi. n must be in [0, 128], otherwise FunnelShiftRight
+// will panic. FunnelShiftRight(u, u, n) == u.RotateLeft(-int(n)).
+func FunnelShiftRight(hi, lo Uint128, n uint) Uint128 {
+	switch {
+	case n == 0:
+		return lo
+	case n < 128:
+		return lo.Rsh(n).Or(hi.Lsh(128 - n))
+	case n == 128:
+		return hi
+	default:
+		panic("u128: funnel shift out of range")
+	}
+}
+
+// CompressBits gathers the bits of u selected by mask into the low bits of the
+// result, preserving their order (PEXT). The remaining high bits are zero.
+func (u Uint128) CompressBits(mask Uint128) Uint128 {
+	lo := pext64(u.lo, mask.lo)
+	hi := pext64(u.hi, mask.hi)
+	return Uint128{lo: hi}.Lsh(uint(OnesCount64(mask.lo))).Or64(lo)
+}
+
+// ExpandBits scatters the low bits of u into the positions set in mask,
+// preserving their order (PDEP). It is the inverse of CompressBits, in that
+// u.CompressBits(mask).ExpandBits(mask) == u.And(mask).
+func (u Uint128) ExpandBits(mask Uint128) Uint128 {
+	lo := pdep64(u.lo, mask.lo)
+	hi := pdep64(u.Rsh(uint(OnesCount64(mask.lo))).lo, mask.hi)
+	return Uint128{hi: hi, lo: lo}
+}
+
+// pext64 is a software PEXT: it loops over the set bits of m, so it costs one
+// iteration per selected bit.
+func pext64(x, m Uint64) (out Uint64) {
+	for k := uint(0); m != 0; k++ {
+		out |= ((x >> uint(TrailingZeros64(m))) & 1) << k
+		m &= m - 1
+	}
+	return out
+}
+
+// pdep64 is a software PDEP; see pext64.
+func pdep64(x, m Uint64) (out Uint64) {
+	for ; m != 0; x >>= 1 {
+		if x&1 != 0 {
+			out |= m & -m
+		}
+		m &= m - 1
+	}
+	return out
+}
+
 func (u Uint128) LeadingZeros() uint {
 	if u.hi == 0 {
 		return uint(LeadingZeros64(u.lo)) + 64
@@ -763,6 +1644,38 @@ func (u Uint128) TrailingZeros() uint {
 	}
 }
 
+// LeadingZerosInt is LeadingZeros returning an int, to match the signature of
+// math/bits.LeadingZeros64. The result is 128 for u == 0.
+func (u Uint128) LeadingZerosInt() int { return int(u.LeadingZeros()) }
+
+// TrailingZerosInt is TrailingZeros returning an int, to match the signature of
+// math/bits.TrailingZeros64. The result is 128 for u == 0.
+func (u Uint128) TrailingZerosInt() int { return int(u.TrailingZeros()) }
+
+// EachSetBit calls fn with the index of each set bit in u, from least to most
+// significant, stopping early if fn returns false. The loop runs once per set
+// bit rather than once per bit, so it's cheap for sparse values.
+func (u Uint128) EachSetBit(fn func(i int) bool) {
+	for !u.IsZero() {
+		if !fn(u.TrailingZerosInt()) {
+			return
+		}
+		u = u.And(u.Dec()) // clear the lowest set bit
+	}
+}
+
+// EachSetBitReverse is like EachSetBit, but walks the set bits from most to
+// least significant.
+func (u Uint128) EachSetBitReverse(fn func(i int) bool) {
+	for !u.IsZero() {
+		i := u.BitLen() - 1
+		if !fn(i) {
+			return
+		}
+		u = u.SetBit(i, 0)
+	}
+}
+
 // Hacker's delight 9-4, divlu:
 func quo128by64(u1, u0, v Uint64, vLeading0 uint) (q Uint64) {
 	var b Uint64 = 1 << 32
@@ -978,14 +1891,82 @@ func quo128bin(u, by Uint128, uLeading0, byLeading0 uint) (q Uint128) {
 	return q
 }
 
+// TextMarshalBase is the base used by Uint128.MarshalText and Int128.MarshalText.
+// It must be 2, 8, 10 or 16. Non-decimal output carries a Go-style "0b", "0o"
+// or "0x" prefix so UnmarshalText can read it back.
+var TextMarshalBase = 10
+
+// textBasePrefix returns the prefix MarshalTextBase writes for base.
+func textBasePrefix(base int) (string, error) {
+	switch base {
+	case 2:
+		return "0b", nil
+	case 8:
+		return "0o", nil
+	case 10:
+		return "", nil
+	case 16:
+		return "0x", nil
+	default:
+		return "", fmt.Errorf("num: unsupported text base %d", base)
+	}
+}
+
+// hasTextBasePrefix reports whether s, after an optional sign, starts with one
+// of the prefixes written by MarshalTextBase.
+func hasTextBasePrefix(s string) bool {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	if len(s) < 3 || s[0] != '0' {
+		return false
+	}
+	switch s[1] {
+	case 'b', 'B', 'o', 'O', 'x', 'X':
+		return true
+	}
+	return false
+}
+
 func (u Uint128) MarshalText() ([]byte, error) {
-	return []byte(u.String()), nil
+	return u.MarshalTextBase(TextMarshalBase)
 }
 
+// MarshalTextBase is like MarshalText, but formats u in the given base rather
+// than TextMarshalBase. See TextMarshalBase for the supported bases.
+func (u Uint128) MarshalTextBase(base int) ([]byte, error) {
+	if base == 10 {
+		return []byte(u.String()), nil
+	}
+	prefix, err := textBasePrefix(base)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(prefix + u.Text(base)), nil
+}
+
+// UnmarshalText accepts decimal, or any of the prefixed forms written by
+// MarshalTextBase. Values outside the range of a Uint128 are an error.
 func (u *Uint128) UnmarshalText(bts []byte) (err error) {
-	v, _, err := Uint128FromString(string(bts))
+	s := string(bts)
+	if hasTextBasePrefix(s) {
+		b, ok := new(big.Int).SetString(s, 0)
+		if !ok || b.Sign() < 0 {
+			return fmt.Errorf("num: u128 string %q invalid", s)
+		}
+		v, inRange := Uint128FromBigInt(b)
+		if !inRange {
+			return fmt.Errorf("num: u128 string %q out of range", s)
+		}
+		*u = v
+		return nil
+	}
+
+	v, inRange, err := Uint128FromBase(s, 10)
 	if err != nil {
 		return err
+	} else if !inRange {
+		return fmt.Errorf("num: u128 string %q out of range", s)
 	}
 	*u = v
 	return nil
@@ -1004,7 +1985,8 @@ func (u *Uint128) UnmarshalJSON(bts []byte) (err error) {
 		bts = bts[1 : ln-1]
 	}
 
-	v, _, err := Uint128FromString(string(bts))
+	// JSON numbers are always decimal, so "010" is ten, not eight:
+	v, _, err := Uint128FromBase(string(bts), 10)
 	if err != nil {
 		return err
 	}
@@ -1012,6 +1994,52 @@ func (u *Uint128) UnmarshalJSON(bts []byte) (err error) {
 	return nil
 }
 
+// MarshalUint128Slice encodes vs as a JSON array of quoted decimal strings,
+// matching the element encoding of MarshalJSON. A nil slice encodes as "[]".
+func MarshalUint128Slice(vs []Uint128) ([]byte, error) {
+	out := make([]byte, 0, 2+len(vs)*8)
+	out = append(out, '[')
+	for i, v := range vs {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		out = append(out, '"')
+		out = append(out, v.String()...)
+		out = append(out, '"')
+	}
+	return append(out, ']'), nil
+}
+
+// UnmarshalUint128Slice decodes a JSON array whose elements may be either
+// quoted decimal strings or bare JSON numbers. Empty strings, null, overflowing
+// values and any other non-numeric element are rejected rather than decoded.
+func UnmarshalUint128Slice(b []byte) ([]Uint128, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, nil
+	}
+
+	out := make([]Uint128, len(raw))
+	for i, tok := range raw {
+		if len(tok) >= 2 && tok[0] == '"' && tok[len(tok)-1] == '"' {
+			tok = tok[1 : len(tok)-1]
+		}
+		if len(tok) == 0 || tok[0] < '0' || tok[0] > '9' {
+			return nil, fmt.Errorf("num: u128 invalid JSON array element %d %q", i, string(raw[i]))
+		}
+		v, inRange, err := Uint128FromBase(string(tok), 10)
+		if err != nil {
+			return nil, err
+		} else if !inRange {
+			return nil, fmt.Errorf("num: u128 JSON array element %d %q out of range", i, string(raw[i]))
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
 // Put big-endian encoded bytes representing this Uint128 into byte slice b.
 // len(b) must be >= 16.
 func (u Uint128) PutBigEndian(b []byte) {
@@ -1031,6 +2059,59 @@ func MustUint128FromBigEndian(b []byte) Uint128 {
 	}
 }
 
+// Bytes returns u as a newly allocated big-endian slice with no leading zero
+// bytes, like big.Int.Bytes. Zero is an empty slice. Use PutBigEndian for the
+// fixed 16-byte form.
+func (u Uint128) Bytes() []byte {
+	var b [16]byte
+	u.PutBigEndian(b[:])
+	n := (u.BitLen() + 7) / 8
+	out := make([]byte, n)
+	copy(out, b[16-n:])
+	return out
+}
+
+// Uint128FromBytes interprets b as a big-endian unsigned integer, like
+// big.Int.SetBytes. b may be anywhere from 0 to 16 bytes long, and leading zero
+// bytes are allowed; ok is false if b is longer than 16 bytes.
+func Uint128FromBytes(b []byte) (out Uint128, ok bool) {
+	if len(b) > 16 {
+		return out, false
+	}
+	var full [16]byte
+	copy(full[16-len(b):], b)
+	return MustUint128FromBigEndian(full[:]), true
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The result is the 16-byte
+// big-endian form written by PutBigEndian.
+func (u Uint128) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 16)
+	u.PutBigEndian(b)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, accepting exactly the
+// 16 bytes written by MarshalBinary.
+func (u *Uint128) UnmarshalBinary(b []byte) error {
+	if len(b) != 16 {
+		return fmt.Errorf("num: u128 binary data must be 16 bytes, found %d", len(b))
+	}
+	*u = MustUint128FromBigEndian(b)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. Without it, encoding/gob would silently
+// drop the unexported fields. The encoding is the same as MarshalBinary.
+func (u Uint128) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (u *Uint128) GobDecode(b []byte) error {
+	return u.UnmarshalBinary(b)
+}
+
 // Put little-endian encoded bytes representing this Uint128 into byte slice b.
 // len(b) must be >= 16.
 func (u Uint128) PutLittleEndian(b []byte) {
@@ -1050,6 +2131,31 @@ func MustUint128FromLittleEndian(b []byte) Uint128 {
 	}
 }
 
+// RandUint128Range returns a uniformly distributed Uint128 in [lo, hi) drawn
+// from r. Values are generated by rejection sampling over the width of the
+// range, so unlike taking a random Uint128 modulo the width, the result is
+// unbiased.
+//
+// If lo and hi are both zero, the entire Uint128 space is sampled. Otherwise,
+// RandUint128Range panics if lo >= hi.
+func RandUint128Range(r *rand.Rand, lo, hi Uint128) Uint128 {
+	if lo.IsZero() && hi.IsZero() {
+		return Uint128{hi: Uint64(r.Uint64()), lo: Uint64(r.Uint64())}
+	}
+	if lo.GreaterOrEqualTo(hi) {
+		panic(fmt.Errorf("num: invalid Uint128 range [%s, %s)", lo, hi))
+	}
+
+	width := hi.Sub(lo)
+	mask := MaxUint128.Rsh(128 - uint(width.Dec().BitLen()))
+	for {
+		v := Uint128{hi: Uint64(r.Uint64()), lo: Uint64(r.Uint64())}.And(mask)
+		if v.LessThan(width) {
+			return lo.Add(v)
+		}
+	}
+}
+
 // DifferenceUint128 subtracts the smaller of a and b from the larger.
 func DifferenceUint128(a, b Uint128) Uint128 {
 	if a.hi > b.hi {
@@ -1090,6 +2196,34 @@ func SmallerUint128(a, b Uint128) Uint128 {
 	return a
 }
 
+// SmallestUint128 returns the smallest of vs. It is the variadic form of
+// SmallerUint128, and panics if vs is empty.
+//
+// It isn't called MinUint128 to avoid confusion with the MaxUint128 constant.
+func SmallestUint128(vs ...Uint128) Uint128 {
+	if len(vs) == 0 {
+		panic("num: SmallestUint128 called with no values")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		out = SmallerUint128(out, v)
+	}
+	return out
+}
+
+// LargestUint128 returns the largest of vs. It is the variadic form of
+// LargerUint128, and panics if vs is empty.
+func LargestUint128(vs ...Uint128) Uint128 {
+	if len(vs) == 0 {
+		panic("num: LargestUint128 called with no values")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		out = LargerUint128(out, v)
+	}
+	return out
+}
+
 // Add64 returns the sum with carry of x, y and carry: sum = x + y + carry.
 // The carry input must be 0 or 1; otherwise the behavior is undefined.
 // The carryOut output is guaranteed to be 0 or 1.
@@ -1,12 +1,16 @@
 package geometry
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
+	mathrand "math/rand"
 	"regexp"
 	"strings"
 	"testing"
@@ -82,6 +86,22 @@ func TestLargerSmallerUint128(t *testing.T) {
 	}
 }
 
+func TestSmallestLargestUint128(t *testing.T) {
+	require.Equal(t, u64(5), SmallestUint128(u64(5)))
+	require.Equal(t, u64(5), LargestUint128(u64(5)))
+
+	vs := []Uint128{u64(3), MaxUint128, u64(0), u64(maxUint64), u64(1).Lsh(64), u64(3)}
+	require.Equal(t, u64(0), SmallestUint128(vs...))
+	require.Equal(t, MaxUint128, LargestUint128(vs...))
+
+	// The hi limb decides, even when the lo limb is smaller:
+	require.Equal(t, u64(maxUint64), SmallestUint128(u64(1).Lsh(64), u64(maxUint64)))
+	require.Equal(t, u64(1).Lsh(64), LargestUint128(u64(maxUint64), u64(1).Lsh(64)))
+
+	require.Panics(t, func() { SmallestUint128() })
+	require.Panics(t, func() { LargestUint128() })
+}
+
 func TestMustUint128FromI64(t *testing.T) {
 	assert := func(ok bool, expected Uint128, v int64) {
 		if !ok {
@@ -113,6 +133,165 @@ func TestMustUint128FromString(t *testing.T) {
 	assert(false, u64(0), "120481092481092840918209481092380192830912830918230918")
 }
 
+func TestUint128MarshalBinary(t *testing.T) {
+	var _ encoding.BinaryMarshaler = Uint128{}
+	var _ encoding.BinaryUnmarshaler = &Uint128{}
+
+	bts := make([]byte, 16)
+	check := func(u Uint128) {
+		b, err := u.MarshalBinary()
+		require.NoError(t, err)
+		require.Len(t, b, 16)
+		require.Equal(t, u, MustUint128FromBigEndian(b))
+
+		var out Uint128
+		require.NoError(t, out.UnmarshalBinary(b))
+		require.Equal(t, u, out)
+	}
+	for _, u := range []Uint128{u64(0), u64(1), u64(maxUint64), u64(1).Lsh(64), MaxUint128} {
+		check(u)
+	}
+	for i := 0; i < 100; i++ {
+		check(randUint128(bts))
+	}
+
+	b, _ := u128s("0x0102030405060708 090a0b0c0d0e0f10").MarshalBinary()
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, b)
+
+	var out Uint128
+	require.Error(t, out.UnmarshalBinary(make([]byte, 15)))
+	require.Error(t, out.UnmarshalBinary(make([]byte, 17)))
+	require.Error(t, out.UnmarshalBinary(nil))
+}
+
+func TestUint128Bytes(t *testing.T) {
+	require.Equal(t, []byte{}, u64(0).Bytes())
+	require.Equal(t, []byte{1}, u64(1).Bytes())
+	require.Equal(t, []byte{1, 0}, u64(256).Bytes())
+	require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 0}, u64(1).Lsh(64).Bytes())
+	require.Equal(t, bytes.Repeat([]byte{0xff}, 16), MaxUint128.Bytes())
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randUint128(bts)
+		b := u.Bytes()
+		require.Equal(t, u.AsBigInt().Bytes(), b, "%s", u)
+
+		out, ok := Uint128FromBytes(b)
+		require.True(t, ok)
+		require.Equal(t, u, out)
+	}
+
+	for _, tc := range []struct {
+		in  []byte
+		out Uint128
+		ok  bool
+	}{
+		{nil, u64(0), true},
+		{[]byte{}, u64(0), true},
+		{[]byte{0, 0, 7}, u64(7), true},
+		{[]byte{1, 2}, u64(0x102), true},
+		{make([]byte, 16), u64(0), true},
+		{bytes.Repeat([]byte{0xff}, 16), MaxUint128, true},
+		{make([]byte, 17), u64(0), false},
+	} {
+		out, ok := Uint128FromBytes(tc.in)
+		require.Equal(t, tc.ok, ok, "%x", tc.in)
+		require.Equal(t, tc.out, out, "%x", tc.in)
+	}
+}
+
+func TestUint128Text(t *testing.T) {
+	bts := make([]byte, 16)
+	values := []Uint128{
+		u64(0), u64(1), u64(35), u64(maxUint64), u64(1).Lsh(64), u64(1).Lsh(64).Dec(),
+		u64(1).Lsh(127), MaxUint128, MaxUint128.Dec(),
+		u128s("10000000000000000000"), u128s("100000000000000000000000000000000000000"),
+	}
+	for i := 0; i < 200; i++ {
+		values = append(values, randUint128(bts))
+	}
+
+	for base := 2; base <= 36; base++ {
+		for _, u := range values {
+			expected := u.AsBigInt().Text(base)
+			require.Equal(t, expected, u.Text(base), "%s in base %d", u, base)
+			require.Equal(t, expected, FormatUint128(u, base))
+		}
+	}
+
+	for _, u := range values {
+		require.Equal(t, u.AsBigInt().String(), u.String())
+	}
+
+	require.Panics(t, func() { u64(1).Text(1) })
+	require.Panics(t, func() { u64(1).Text(37) })
+}
+
+func TestUint128FromStringSeparators(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		out Uint128
+		ok  bool
+	}{
+		{"18_446_744_073_709_551_616", u64(1).Lsh(64), true},
+		{"1_000_000", u64(1000000), true},
+		{"0xffff_ffff", u64(0xffffffff), true},
+		{"0b1_0", u64(2), true},
+		{"_1", zeroUint128, false},
+		{"1_", zeroUint128, false},
+		{"1__2", zeroUint128, false},
+		{"_", zeroUint128, false},
+	} {
+		out, _, err := Uint128FromString(tc.in)
+		require.Equal(t, tc.ok, err == nil, "%q: %v", tc.in, err)
+		require.Equal(t, tc.out, out, "%q", tc.in)
+	}
+
+	// Forcing a base follows big.Int and doesn't allow separators:
+	_, _, err := Uint128FromBase("1_000", 10)
+	require.Error(t, err)
+}
+
+func TestUint128FromBase(t *testing.T) {
+	for idx, tc := range []struct {
+		in      string
+		base    int
+		out     Uint128
+		inRange bool
+		ok      bool
+	}{
+		{"010", 10, u64(10), true, true},
+		{"010", 0, u64(8), true, true},
+		{"010", 8, u64(8), true, true},
+		{"ff", 16, u64(255), true, true},
+		{"0xff", 16, zeroUint128, false, false}, // forcing a base disables prefixes
+		{"0xff", 0, u64(255), true, true},
+		{"z", 36, u64(35), true, true},
+		{"11", 2, u64(3), true, true},
+		{"12", 2, zeroUint128, false, false},
+		{"340282366920938463463374607431768211455", 10, MaxUint128, true, true},
+		{"340282366920938463463374607431768211456", 10, MaxUint128, false, true},
+		{"1", 1, zeroUint128, false, false},
+		{"1", 63, zeroUint128, false, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%d", idx, tc.in, tc.base), func(t *testing.T) {
+			out, inRange, err := Uint128FromBase(tc.in, tc.base)
+			require.Equal(t, tc.ok, err == nil, "%v", err)
+			require.Equal(t, tc.inRange, inRange)
+			require.Equal(t, tc.out, out)
+		})
+	}
+
+	require.Equal(t, u64(255), MustUint128FromString("0xff"))
+
+	// JSON stays decimal-only:
+	var u Uint128
+	require.NoError(t, json.Unmarshal([]byte(`"010"`), &u))
+	require.Equal(t, u64(10), u)
+	require.Error(t, json.Unmarshal([]byte(`"0x10"`), &u))
+}
+
 func TestUint128Add(t *testing.T) {
 	for _, tc := range []struct {
 		a, b, c Uint128
@@ -145,6 +324,45 @@ func TestUint128Add64(t *testing.T) {
 	}
 }
 
+func TestUint128AddN(t *testing.T) {
+	t.Run("sequence", func(t *testing.T) {
+		const stride = 0x1234567890ABCDEF
+
+		seq := u64(maxUint64 - 5*stride) // crosses into the hi limb part way through
+		cur := seq
+		for i := 0; i < 20; i++ {
+			var overflow bool
+			seq, overflow = seq.AddN(stride, 3)
+			require.False(t, overflow)
+			for j := 0; j < 3; j++ {
+				cur = cur.Add64(stride)
+			}
+			require.Equal(t, cur, seq)
+		}
+	})
+
+	for idx, tc := range []struct {
+		u             Uint128
+		stride, count uint64
+		out           Uint128
+		overflow      bool
+	}{
+		{u64(0), 0, 0, u64(0), false},
+		{u64(0), maxUint64, maxUint64, u128s("0xFFFFFFFFFFFFFFFE 0000000000000001"), false},
+		{MaxUint128, 0, 100, MaxUint128, false},
+		{MaxUint128.Sub64(10), 5, 2, MaxUint128, false},
+		{MaxUint128.Sub64(10), 5, 3, u64(4), true},
+		{MaxUint128, 1, 1, u64(0), true},
+		{u128s("0xFFFFFFFFFFFFFFFF 0000000000000000"), maxUint64, maxUint64, u128s("0xFFFFFFFFFFFFFFFD 0000000000000001"), true},
+	} {
+		t.Run(fmt.Sprintf("%d/%s+%d*%d", idx, tc.u, tc.stride, tc.count), func(t *testing.T) {
+			out, overflow := tc.u.AddN(tc.stride, tc.count)
+			require.Equal(t, tc.overflow, overflow)
+			require.Equal(t, tc.out, out)
+		})
+	}
+}
+
 func TestUint128AsBigInt(t *testing.T) {
 	for idx, tc := range []struct {
 		a Uint128
@@ -221,6 +439,297 @@ func TestUint128AsFloat64Epsilon(t *testing.T) {
 	}
 }
 
+func TestUint128AsBigFloatExact(t *testing.T) {
+	bts := make([]byte, 16)
+	check := func(u Uint128) {
+		f := u.AsBigFloatExact()
+		require.Equal(t, uint(128), f.Prec())
+		bi, acc := f.Int(nil)
+		require.Equal(t, big.Exact, acc)
+		require.True(t, u.AsBigInt().Cmp(bi) == 0, "%s != %s", u, bi)
+	}
+	for _, u := range []Uint128{u64(0), u64(1), u64(maxUint64), MaxUint128, MaxUint128.Dec()} {
+		check(u)
+	}
+	for i := 0; i < 1000; i++ {
+		check(randUint128(bts))
+	}
+
+	// big.Float arithmetic rounds to the receiver's precision. AsBigFloat(1)
+	// only has 64 bits, so accumulating into it loses the low bits, but the
+	// exact form keeps them:
+	lossy := u64(1).AsBigFloat()
+	lossy.Add(lossy, MaxUint128.Dec().AsBigFloat())
+	bi, acc := lossy.Int(nil)
+	require.Equal(t, big.Exact, acc)
+	require.False(t, MaxUint128.AsBigInt().Cmp(bi) == 0)
+
+	exact := u64(1).AsBigFloatExact()
+	exact.Add(exact, MaxUint128.Dec().AsBigFloatExact())
+	bi, _ = exact.Int(nil)
+	require.True(t, MaxUint128.AsBigInt().Cmp(bi) == 0, "%s", bi)
+}
+
+func TestUint128Between(t *testing.T) {
+	for idx, tc := range []struct {
+		u, lo, hi Uint128
+		out       bool
+	}{
+		{u64(1), u64(0), u64(2), true},
+		{u64(0), u64(0), u64(2), true},
+		{u64(2), u64(0), u64(2), true},
+		{u64(3), u64(0), u64(2), false},
+		{u64(1), u64(2), u64(3), false},
+		{MaxUint128, u64(0), MaxUint128, true},
+		{u64(maxUint64), u64(0), u128s("18446744073709551616"), true},
+		{u128s("18446744073709551617"), u64(0), u128s("18446744073709551616"), false},
+		{u64(1), u64(2), u64(0), false}, // inverted bounds
+		{u64(2), u64(2), u64(0), false}, // inverted bounds
+	} {
+		t.Run(fmt.Sprintf("%d/%s<=%s<=%s", idx, tc.lo, tc.u, tc.hi), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.Between(tc.lo, tc.hi))
+		})
+	}
+}
+
+func TestUint128Clamp(t *testing.T) {
+	for idx, tc := range []struct {
+		u, lo, hi Uint128
+		out       Uint128
+	}{
+		{u64(1), u64(0), u64(2), u64(1)},
+		{u64(0), u64(1), u64(2), u64(1)},
+		{u64(3), u64(0), u64(2), u64(2)},
+		{u64(2), u64(2), u64(2), u64(2)},
+		{MaxUint128, u64(0), u64(maxUint64), u64(maxUint64)},
+		{u64(0), u64(1).Lsh(64), MaxUint128, u64(1).Lsh(64)},
+		{MaxUint128, u64(0), MaxUint128, MaxUint128},
+	} {
+		t.Run(fmt.Sprintf("%d/clamp(%s,%s,%s)", idx, tc.u, tc.lo, tc.hi), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.Clamp(tc.lo, tc.hi))
+		})
+	}
+
+	require.Panics(t, func() { u64(1).Clamp(u64(2), u64(1)) })
+}
+
+// bigClMul is the reference GF(2) polynomial multiply: XOR together a copy of
+// a shifted left by each set bit position of b.
+func bigClMul(a, b *big.Int) *big.Int {
+	out := new(big.Int)
+	for i := 0; i < b.BitLen(); i++ {
+		if b.Bit(i) == 1 {
+			out.Xor(out, new(big.Int).Lsh(a, uint(i)))
+		}
+	}
+	return out
+}
+
+func TestUint128ClMul(t *testing.T) {
+	check := func(t *testing.T, a, b Uint128) {
+		hi, lo := a.ClMul(b)
+		result := new(big.Int).Lsh(hi.AsBigInt(), 128)
+		result.Or(result, lo.AsBigInt())
+		expected := bigClMul(a.AsBigInt(), b.AsBigInt())
+		require.True(t, expected.Cmp(result) == 0, "%s clmul %s: expected %x, found %x", a, b, expected, result)
+
+		hi2, lo2 := b.ClMul(a)
+		require.Equal(t, hi, hi2)
+		require.Equal(t, lo, lo2)
+	}
+
+	highBit := u64(1).Lsh(127)
+	for idx, tc := range []struct {
+		a, b   Uint128
+		hi, lo Uint128
+	}{
+		{u64(0), MaxUint128, u64(0), u64(0)},
+		{u64(1), MaxUint128, u64(0), MaxUint128},
+		{u64(3), u64(3), u64(0), u64(5)}, // (x+1)^2 == x^2+1
+		{u64(2), highBit, u64(1), u64(0)},
+		{highBit, highBit, u64(1).Lsh(126), u64(0)},
+		{MaxUint128, MaxUint128, u128s("0x55555555555555555555555555555555"), u128s("0x55555555555555555555555555555555")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s*%s", idx, tc.a, tc.b), func(t *testing.T) {
+			hi, lo := tc.a.ClMul(tc.b)
+			require.Equal(t, tc.hi, hi)
+			require.Equal(t, tc.lo, lo)
+			check(t, tc.a, tc.b)
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		bts := make([]byte, 16)
+		for i := 0; i < 1000; i++ {
+			check(t, randUint128(bts), randUint128(bts))
+		}
+	})
+}
+
+func TestUint128GFMul(t *testing.T) {
+	one, x := u64(1), u64(2)
+	x127 := u64(1).Lsh(127)
+
+	require.Equal(t, u64(0x87), x127.GFMul(x)) // x^128 == x^7 + x^2 + x + 1
+	require.Equal(t, u64(0x87).Lsh(1), x127.GFMul(u64(4)))
+	require.Equal(t, ReduceGHASH(u64(0), MaxUint128), MaxUint128)
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		a, b, c := randUint128(bts), randUint128(bts), randUint128(bts)
+		require.Equal(t, a, a.GFMul(one))
+		require.Equal(t, a.GFMul(b), b.GFMul(a))
+		require.Equal(t, a.GFMul(b).GFMul(c), a.GFMul(b.GFMul(c)))
+		require.Equal(t, a.GFMul(b.Xor(c)), a.GFMul(b).Xor(a.GFMul(c)))
+	}
+}
+
+func TestUint128GFMulGHASH(t *testing.T) {
+	// GCM stores field elements bit-reflected, so reverse all 128 bits of each
+	// big-endian block on the way in and out:
+	block := func(s string) Uint128 {
+		u := u128s("0x" + s)
+		return Uint128FromRaw(Reverse64(u.lo), Reverse64(u.hi))
+	}
+
+	// NIST GCM spec test case 2: all-zero key, IV and plaintext.
+	h := block("66e94bd4ef8a2c3b884cfa59ca342b2e")
+	c := block("0388dace60b6a392f328c2b971b2fe78")
+	lens := block("00000000000000000000000000000080") // len(A) == 0, len(C) == 128 bits
+
+	var y Uint128
+	y = y.Xor(c).GFMul(h)
+	y = y.Xor(lens).GFMul(h)
+	require.Equal(t, block("f38cbb1ad69223dcc3457ae5b6b0f885"), y)
+}
+
+func TestFunnelShiftWindows(t *testing.T) {
+	// Left takes bits [128-n, 256-n) of hi<<128 | lo, and right takes
+	// bits [n, n+128):
+	hi := u128s("0x0123456789ABCDEF FEDCBA9876543210")
+	lo := u128s("0x89ABCDEF01234567 76543210FEDCBA98")
+	for idx, tc := range []struct {
+		n           uint
+		left, right Uint128
+	}{
+		{0, hi, lo},
+		{64, u128s("0xFEDCBA9876543210 89ABCDEF01234567"), u128s("0xFEDCBA9876543210 89ABCDEF01234567")},
+		{127, u128s("0x44D5E6F78091A2B3 BB2A19087F6E5D4C"), u128s("0x02468ACF13579BDF FDB97530ECA86421")},
+		{128, lo, hi},
+	} {
+		t.Run(fmt.Sprintf("%d/%d", idx, tc.n), func(t *testing.T) {
+			require.Equal(t, tc.left, FunnelShiftLeft(hi, lo, tc.n))
+			require.Equal(t, tc.right, FunnelShiftRight(hi, lo, tc.n))
+		})
+	}
+}
+
+func TestFunnelShift(t *testing.T) {
+	window := func(hi, lo Uint128, from uint) Uint128 {
+		v := new(big.Int).Lsh(hi.AsBigInt(), 128)
+		v.Or(v, lo.AsBigInt()).Rsh(v, from).And(v, maxBigUint128)
+		return MustUint128FromBigInt(v)
+	}
+
+	bts := make([]byte, 16)
+	pairs := [][2]Uint128{
+		{u64(0), MaxUint128},
+		{MaxUint128, u64(0)},
+		{u128s("0x0123456789ABCDEF FEDCBA9876543210"), u128s("0x89ABCDEF01234567 76543210FEDCBA98")},
+	}
+	for i := 0; i < 20; i++ {
+		pairs = append(pairs, [2]Uint128{randUint128(bts), randUint128(bts)})
+	}
+
+	for idx, p := range pairs {
+		hi, lo := p[0], p[1]
+		t.Run(fmt.Sprintf("%d/%s,%s", idx, hi, lo), func(t *testing.T) {
+			for n := uint(0); n <= 128; n++ {
+				require.Equal(t, window(hi, lo, 128-n), FunnelShiftLeft(hi, lo, n), "left %d", n)
+				require.Equal(t, window(hi, lo, n), FunnelShiftRight(hi, lo, n), "right %d", n)
+			}
+			for n := 0; n < 128; n++ {
+				require.Equal(t, hi.RotateLeft(n), FunnelShiftLeft(hi, hi, uint(n)))
+				require.Equal(t, hi.RotateLeft(-n), FunnelShiftRight(hi, hi, uint(n)))
+			}
+		})
+	}
+
+	require.Panics(t, func() { FunnelShiftLeft(u64(1), u64(1), 129) })
+	require.Panics(t, func() { FunnelShiftRight(u64(1), u64(1), 129) })
+}
+
+func TestUint128CompressExpandBits(t *testing.T) {
+	for idx, tc := range []struct {
+		u, mask, compressed Uint128
+	}{
+		{u64(0b1011_0110), u64(0b1111_0000), u64(0b1011)},
+		{u64(0b1011_0110), u64(0b0101_0101), u64(0b0110)},
+		{MaxUint128, MaxUint128, MaxUint128},
+		{MaxUint128, u64(0), u64(0)},
+		{u128s("0x8000000000000001 8000000000000001"), u128s("0x8000000000000000 8000000000000001"), u64(0b111)},
+		{u128s("0xFFFF0000FFFF0000 0000000000000000"), u128s("0xFFFFFFFF00000000 FFFFFFFFFFFFFFFF"), u128s("0xFFFF0000 0000000000000000")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s&%s", idx, tc.u, tc.mask), func(t *testing.T) {
+			require.Equal(t, tc.compressed, tc.u.CompressBits(tc.mask))
+			require.Equal(t, tc.u.And(tc.mask), tc.compressed.ExpandBits(tc.mask))
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v, m := randUint128(bts), randUint128(bts)
+		c := v.CompressBits(m)
+		require.Equal(t, v.And(m), c.ExpandBits(m))
+
+		// Only the low OnesCount(m) bits of the result may be set, and compressing
+		// an expanded value gives back those low bits:
+		n := uint(OnesCount64(m.hi) + OnesCount64(m.lo))
+		low := MaxUint128
+		if n < 128 {
+			low = u64(1).Lsh(n).Dec()
+		}
+		require.Equal(t, c, c.And(low))
+		require.Equal(t, v.And(low), v.ExpandBits(m).CompressBits(m))
+	}
+}
+
+func TestUint128DecimalChunks(t *testing.T) {
+	for idx, tc := range []struct {
+		u      Uint128
+		chunks []uint64
+	}{
+		{u64(0), []uint64{0}},
+		{u64(999999999999999999), []uint64{999999999999999999}},
+		{u64(maxUint64), []uint64{18, 446744073709551615}},
+		{u128s("18446744073709551616"), []uint64{18, 446744073709551616}},
+		{u128s("1000000000000000000000000000000000000"), []uint64{1, 0, 0}},
+		{MaxUint128, []uint64{340, 282366920938463463, 374607431768211455}},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.u), func(t *testing.T) {
+			require.Equal(t, tc.chunks, tc.u.DecimalChunks())
+
+			out, ok := Uint128FromDecimalChunks(tc.chunks)
+			require.True(t, ok)
+			require.Equal(t, tc.u, out)
+		})
+	}
+}
+
+func TestUint128FromDecimalChunksOverflow(t *testing.T) {
+	for idx, chunks := range [][]uint64{
+		{341, 0, 0},
+		{340, 282366920938463463, 374607431768211456},
+		{1, 0, 0, 0},
+		{1000000000000000000},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			_, ok := Uint128FromDecimalChunks(chunks)
+			require.False(t, ok)
+		})
+	}
+}
+
 func TestUint128Dec(t *testing.T) {
 	for _, tc := range []struct {
 		a, b Uint128
@@ -238,6 +747,45 @@ func TestUint128Dec(t *testing.T) {
 	}
 }
 
+func TestUint128FastRangeN(t *testing.T) {
+	for idx, tc := range []struct {
+		u   Uint128
+		n   uint64
+		out uint64
+	}{
+		{u64(0), 10, 0},
+		{MaxUint128, 10, 9},
+		{MaxUint128, 0, 0},
+		{Uint128{hi: 0x8000000000000000}, 10, 5},
+		{Uint128{hi: 0x8000000000000000}, maxUint64, 0x7FFFFFFFFFFFFFFF},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.u), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.FastRangeN(tc.n))
+		})
+	}
+}
+
+func TestUint128FastRangeNRandom(t *testing.T) {
+	const buckets = 16
+	const samples = 160000
+
+	var counts [buckets]int
+	bts := make([]byte, 16)
+	for i := 0; i < samples; i++ {
+		rand.Read(bts)
+		u := MustUint128FromBigEndian(bts)
+		v := u.FastRangeN(buckets)
+		require.True(t, v < buckets, "%s mapped to %d", u, v)
+		counts[v]++
+	}
+
+	// Each bucket expects 10000 hits; a 10% window is many standard deviations
+	// wide, so this only fails if the mapping is badly skewed:
+	for idx, c := range counts {
+		require.True(t, c > 9000 && c < 11000, "bucket %d has %d hits", idx, c)
+	}
+}
+
 func TestUint128Format(t *testing.T) {
 	for idx, tc := range []struct {
 		v   Uint128
@@ -254,9 +802,8 @@ func TestUint128Format(t *testing.T) {
 		{MaxUint128, "%#o", "03777777777777777777777777777777777777777777"},
 		{MaxUint128, "%#x", "0xffffffffffffffffffffffffffffffff"},
 		{MaxUint128, "%#X", "0XFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"},
-
-		// No idea why big.Int doesn't support this:
-		// {MaxUint128, "%#b", "0b" + strings.Repeat("1", 128)},
+		{MaxUint128, "%#b", "0b" + strings.Repeat("1", 128)},
+		{u64(1).Lsh(64), "%#b", "0b1" + strings.Repeat("0", 64)},
 	} {
 		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.fmt, tc.v), func(t *testing.T) {
 
@@ -266,6 +813,42 @@ func TestUint128Format(t *testing.T) {
 	}
 }
 
+func TestUint128FormatPrefixedBinary(t *testing.T) {
+	// big.Int drops the '#' for 'b', so Uint128 formats it itself. Anything
+	// that fits in a uint64 should come out exactly as fmt prints a uint64:
+	for _, f := range []string{
+		"%#b", "%#1b", "%#8b", "%#-8b", "%#08b", "%#012b", "%#-012b",
+		"%#.0b", "%#.4b", "%#10.4b", "%#-10.4b", "%#010.4b", "%#4.0b",
+		"%+#b", "%+#010b", "% #b", "% #010b", "%+#-10b",
+	} {
+		for _, v := range []uint64{0, 1, 5, 0xff, maxUint64} {
+			require.Equal(t, fmt.Sprintf(f, v), fmt.Sprintf(f, u64(Uint64(v))), "%s of %d", f, v)
+		}
+	}
+
+	require.Equal(t, "  0b1"+strings.Repeat("0", 64), fmt.Sprintf("%#69b", u64(1).Lsh(64)))
+	require.Equal(t, "0b0001"+strings.Repeat("0", 64), fmt.Sprintf("%#.68b", u64(1).Lsh(64)))
+}
+
+func TestUint128FormatStruct(t *testing.T) {
+	type wrapper struct{ U Uint128 }
+
+	for idx, tc := range []struct {
+		in  wrapper
+		f   string
+		out string
+	}{
+		{wrapper{MaxUint128}, "%v", "{340282366920938463463374607431768211455}"},
+		{wrapper{MaxUint128}, "%+v", "{U:340282366920938463463374607431768211455}"},
+		{wrapper{MaxUint128}, "%x", "{ffffffffffffffffffffffffffffffff}"},
+		{wrapper{MaxUint128}, "%X", "{FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF}"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.f), func(t *testing.T) {
+			require.Equal(t, tc.out, fmt.Sprintf(tc.f, tc.in))
+		})
+	}
+}
+
 func TestUint128FromBigInt(t *testing.T) {
 	for idx, tc := range []struct {
 		a   *big.Int
@@ -397,6 +980,67 @@ func TestUint128FromSize(t *testing.T) {
 	assertInRange(u128s("12345"))(Uint128FromFloat64(12345.6))
 }
 
+func TestUint128QFloat(t *testing.T) {
+	for idx, tc := range []struct {
+		f        float64
+		fracBits int
+		out      Uint128
+		inRange  bool
+	}{
+		{0, 16, u64(0), true},
+		{1.5, 0, u64(2), true},
+		{1, 8, u64(256), true},
+		{1, 64, Uint128{hi: 1}, true},
+		{1, 127, Uint128{hi: 1 << 63}, true},
+		{1, 128, MaxUint128, false},
+		{-1, 8, u64(0), false},
+		{-0.001, 8, u64(0), true}, // rounds to zero before the sign matters
+		{math.NaN(), 8, u64(0), false},
+	} {
+		t.Run(fmt.Sprintf("%d/%g,Q%d", idx, tc.f, tc.fracBits), func(t *testing.T) {
+			out, inRange := Uint128FromQFloat(tc.f, tc.fracBits)
+			require.Equal(t, tc.inRange, inRange)
+			require.Equal(t, tc.out, out)
+			if scaled := math.Ldexp(tc.f, tc.fracBits); inRange && scaled == math.Round(scaled) {
+				require.Equal(t, tc.f, out.ToQFloat(tc.fracBits))
+			}
+		})
+	}
+}
+
+func TestUint128GCD(t *testing.T) {
+	for idx, tc := range []struct {
+		u, v, gcd Uint128
+	}{
+		{u64(0), u64(0), u64(0)},
+		{u64(0), u64(7), u64(7)},
+		{u64(7), u64(0), u64(7)},
+		{u64(12), u64(18), u64(6)},
+		{u64(17), u64(5), u64(1)},
+		{u64(1).Lsh(127), u64(1).Lsh(64), u64(1).Lsh(64)},
+		{MaxUint128, MaxUint128, MaxUint128},
+		{MaxUint128, u64(maxUint64), u64(maxUint64)}, // 2^128-1 == (2^64-1)(2^64+1)
+		{MaxUint128, u64(1).Lsh(127), u64(1)},
+	} {
+		t.Run(fmt.Sprintf("%d/gcd(%s,%s)", idx, tc.u, tc.v), func(t *testing.T) {
+			require.Equal(t, tc.gcd, tc.u.GCD(tc.v))
+			require.Equal(t, tc.gcd, tc.v.GCD(tc.u))
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u, v := randUint128(bts), randUint128(bts)
+		if i%2 == 0 {
+			// Random pairs are usually coprime; give them a large common factor:
+			f := u64(Uint64(i + 1)).Lsh(uint(i % 40))
+			u, v = u.Rsh(64).Mul(f), v.Rsh(64).Mul(f)
+		}
+		expected := new(big.Int).GCD(nil, nil, u.AsBigInt(), v.AsBigInt())
+		require.Equal(t, expected.String(), u.GCD(v).String(), "gcd(%s, %s)", u, v)
+	}
+}
+
 func TestUint128Inc(t *testing.T) {
 	for _, tc := range []struct {
 		a, b Uint128
@@ -415,6 +1059,207 @@ func TestUint128Inc(t *testing.T) {
 	}
 }
 
+func TestUint128OnesCount(t *testing.T) {
+	for idx, tc := range []struct {
+		u    Uint128
+		ones int
+	}{
+		{u64(0), 0},
+		{u64(1), 1},
+		{u64(maxUint64), 64},
+		{u64(1).Lsh(64), 1},
+		{u128s("0x1 0000000000000001"), 2},
+		{u128s("0xF0 00000000000000FF"), 12},
+		{MaxUint128, 128},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.u), func(t *testing.T) {
+			require.Equal(t, tc.ones, tc.u.OnesCount())
+		})
+	}
+}
+
+func TestUint128BitsHelpers(t *testing.T) {
+	check := func(u Uint128) {
+		hi, lo := uint64(u.hi), uint64(u.lo)
+
+		ln := bits.Len64(lo)
+		lz := 64 + bits.LeadingZeros64(lo)
+		if hi != 0 {
+			ln, lz = 64+bits.Len64(hi), bits.LeadingZeros64(hi)
+		}
+		tz := bits.TrailingZeros64(lo)
+		if lo == 0 {
+			tz = 64 + bits.TrailingZeros64(hi)
+		}
+
+		require.Equal(t, u.BitLen(), u.Len())
+		require.Equal(t, ln, u.Len(), "len(%s)", u)
+		require.Equal(t, int(u.LeadingZeros()), u.LeadingZerosInt())
+		require.Equal(t, lz, u.LeadingZerosInt(), "lz(%s)", u)
+		require.Equal(t, int(u.TrailingZeros()), u.TrailingZerosInt())
+		require.Equal(t, tz, u.TrailingZerosInt(), "tz(%s)", u)
+		require.Equal(t, bits.OnesCount64(hi)+bits.OnesCount64(lo), u.OnesCount(), "onescount(%s)", u)
+	}
+
+	for _, u := range []Uint128{u64(0), u64(1), u64(maxUint64), u64(1).Lsh(64), u64(1).Lsh(127), MaxUint128} {
+		check(u)
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		check(randUint128(bts))
+	}
+}
+
+func TestUint128Saturating(t *testing.T) {
+	zero, one, max := u64(0), u64(1), MaxUint128
+	loMax := u64(maxUint64)
+	hiOne := u64(1).Lsh(64)
+
+	for _, tc := range []struct {
+		a, b          Uint128
+		add, sub, mul Uint128
+	}{
+		{zero, zero, zero, zero, zero},
+		{zero, one, one, zero, zero},
+		{one, zero, one, one, zero},
+		{one, one, u64(2), zero, one},
+		{max, zero, max, max, zero},
+		{zero, max, max, zero, zero},
+		{max, one, max, max.Dec(), max},
+		{one, max, max, zero, max},
+		{max, max, max, zero, max},
+		{max.Dec(), one, max, max.Dec().Dec(), max.Dec()},
+		{loMax, one, hiOne, loMax.Dec(), loMax},   // carry into hi
+		{hiOne, one, hiOne.Inc(), loMax, hiOne},   // borrow from hi
+		{hiOne, hiOne, u64(2).Lsh(64), zero, max}, // product is exactly 2^128
+		{hiOne, loMax, hiOne.Add(loMax), one, max.Sub(loMax)},
+	} {
+		require.Equal(t, tc.add, tc.a.SaturatingAdd(tc.b), "%s + %s", tc.a, tc.b)
+		require.Equal(t, tc.sub, tc.a.SaturatingSub(tc.b), "%s - %s", tc.a, tc.b)
+		require.Equal(t, tc.mul, tc.a.SaturatingMul(tc.b), "%s * %s", tc.a, tc.b)
+	}
+}
+
+func TestUint128Log(t *testing.T) {
+	require.Equal(t, -1, u64(0).Log2())
+	require.Equal(t, -1, u64(0).Log10())
+	require.Equal(t, 0, u64(1).Log2())
+	require.Equal(t, 127, MaxUint128.Log2())
+	require.Equal(t, 38, MaxUint128.Log10())
+
+	for i := 0; i < 128; i++ {
+		p := u64(1).Lsh(uint(i))
+		require.Equal(t, i, p.Log2(), "log2(2^%d)", i)
+		require.Equal(t, i, MaxUint128.Rsh(uint(127-i)).Log2(), "log2(2^%d+1 - 1)", i+1)
+	}
+
+	// Check either side of every power of ten against big.Int's digit count:
+	ten := big.NewInt(10)
+	p := big.NewInt(1)
+	for i := 0; i <= 38; i++ {
+		for _, b := range []*big.Int{
+			new(big.Int).Sub(p, big1),
+			p,
+			new(big.Int).Add(p, big1),
+		} {
+			if b.Sign() == 0 {
+				continue
+			}
+			u := accUint128FromBigInt(b)
+			require.Equal(t, len(b.String())-1, u.Log10(), "log10(%s)", b)
+		}
+		p.Mul(p, ten)
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randUint128(bts)
+		if u.IsZero() {
+			continue
+		}
+		require.Equal(t, len(u.String())-1, u.Log10(), "log10(%s)", u)
+	}
+}
+
+func TestUint128Midpoint(t *testing.T) {
+	for _, tc := range []struct {
+		a, b, expected Uint128
+	}{
+		{u64(0), u64(0), u64(0)},
+		{u64(0), u64(1), u64(0)},
+		{u64(1), u64(3), u64(2)},
+		{u64(2), u64(7), u64(4)},
+		{MaxUint128, MaxUint128, MaxUint128},
+		{MaxUint128, MaxUint128.Dec(), MaxUint128.Dec()},
+		{MaxUint128, u64(0), MaxUint128.Rsh(1)},
+		{u64(maxUint64), u64(maxUint64).Inc(), u64(maxUint64)},
+		{u64(1).Lsh(127), u64(1).Lsh(127).Add64(2), u64(1).Lsh(127).Inc()},
+	} {
+		require.Equal(t, tc.expected, tc.a.Midpoint(tc.b), "mid(%s, %s)", tc.a, tc.b)
+		require.Equal(t, tc.expected, tc.b.Midpoint(tc.a), "mid(%s, %s)", tc.b, tc.a)
+	}
+
+	bts := make([]byte, 16)
+	two := big.NewInt(2)
+	for i := 0; i < 1000; i++ {
+		a, b := randUint128(bts), randUint128(bts)
+		expected := new(big.Int).Add(a.AsBigInt(), b.AsBigInt())
+		expected.Quo(expected, two)
+		require.Equal(t, accUint128FromBigInt(expected), a.Midpoint(b), "mid(%s, %s)", a, b)
+	}
+}
+
+func TestUint128EachSetBit(t *testing.T) {
+	var u Uint128
+	set := []int{0, 3, 63, 64, 65, 100, 127}
+	for _, i := range set {
+		u = u.SetBit(i, 1)
+	}
+
+	var fwd []int
+	u.EachSetBit(func(i int) bool {
+		fwd = append(fwd, i)
+		return true
+	})
+	require.Equal(t, set, fwd)
+
+	var rev []int
+	u.EachSetBitReverse(func(i int) bool {
+		rev = append(rev, i)
+		return true
+	})
+	require.Equal(t, []int{127, 100, 65, 64, 63, 3, 0}, rev)
+
+	// Early termination:
+	var got []int
+	u.EachSetBit(func(i int) bool {
+		got = append(got, i)
+		return i < 63
+	})
+	require.Equal(t, []int{0, 3, 63}, got)
+
+	got = nil
+	u.EachSetBitReverse(func(i int) bool {
+		got = append(got, i)
+		return false
+	})
+	require.Equal(t, []int{127}, got)
+
+	u64(0).EachSetBit(func(i int) bool {
+		t.Fatal("unexpected bit", i)
+		return true
+	})
+
+	n := 0
+	MaxUint128.EachSetBit(func(i int) bool {
+		require.Equal(t, n, i)
+		n++
+		return true
+	})
+	require.Equal(t, 128, n)
+}
+
 func TestUint128Lsh(t *testing.T) {
 	for idx, tc := range []struct {
 		u  Uint128
@@ -465,6 +1310,306 @@ func TestUint128MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestUint128SliceJSON(t *testing.T) {
+	vs := []Uint128{u64(0), MaxUint128, u64(1), u128s("18446744073709551616")}
+	bts, err := MarshalUint128Slice(vs)
+	require.NoError(t, err)
+	require.Equal(t, `["0","340282366920938463463374607431768211455","1","18446744073709551616"]`, string(bts))
+
+	std, err := json.Marshal(vs)
+	require.NoError(t, err)
+	require.Equal(t, std, bts)
+
+	out, err := UnmarshalUint128Slice(bts)
+	require.NoError(t, err)
+	require.Equal(t, vs, out)
+
+	empty, err := MarshalUint128Slice(nil)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(empty))
+	out, err = UnmarshalUint128Slice(empty)
+	require.NoError(t, err)
+	require.Len(t, out, 0)
+}
+
+func TestUnmarshalUint128Slice(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out []Uint128
+		ok  bool
+	}{
+		{`[0]`, []Uint128{u64(0)}, true},
+		{`["0"]`, []Uint128{u64(0)}, true},
+		{`[340282366920938463463374607431768211455]`, []Uint128{MaxUint128}, true},
+		{`[ 1, "2" ,340282366920938463463374607431768211455, "0" ]`, []Uint128{u64(1), u64(2), MaxUint128, u64(0)}, true},
+		{`null`, nil, true},
+
+		{`[""]`, nil, false},
+		{`["1", ""]`, nil, false},
+		{`[null]`, nil, false},
+		{`[true]`, nil, false},
+		{`[-1]`, nil, false},
+		{`[1.5]`, nil, false},
+		{`[1e3]`, nil, false},
+		{`["-1"]`, nil, false},
+		{`[" 1"]`, nil, false},
+		{`[340282366920938463463374607431768211456]`, nil, false},
+		{`[1,]`, nil, false},
+		{`1`, nil, false},
+		{``, nil, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			out, err := UnmarshalUint128Slice([]byte(tc.in))
+			if !tc.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.out, out)
+		})
+	}
+}
+
+func TestUint128MarshalTextBase(t *testing.T) {
+	for idx, tc := range []struct {
+		a    Uint128
+		base int
+		out  string
+	}{
+		{u64(0), 16, "0x0"},
+		{u64(255), 16, "0xff"},
+		{u64(5), 2, "0b101"},
+		{u64(8), 8, "0o10"},
+		{MaxUint128, 16, "0xffffffffffffffffffffffffffffffff"},
+		{MaxUint128, 10, "340282366920938463463374607431768211455"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			out, err := tc.a.MarshalTextBase(tc.base)
+			require.NoError(t, err)
+			require.Equal(t, tc.out, string(out))
+
+			var v Uint128
+			require.NoError(t, v.UnmarshalText(out))
+			require.Equal(t, tc.a, v)
+		})
+	}
+
+	_, err := u64(1).MarshalTextBase(36)
+	require.Error(t, err)
+
+	var v Uint128
+	require.Error(t, v.UnmarshalText([]byte("-0xff")))
+	require.Error(t, v.UnmarshalText([]byte("0x")))
+
+	// Out of range, so v must be left alone:
+	v = u64(7)
+	require.Error(t, v.UnmarshalText([]byte("0x100000000000000000000000000000000")))
+	require.Error(t, v.UnmarshalText([]byte("0b1"+strings.Repeat("0", 128))))
+	require.Error(t, v.UnmarshalText([]byte("340282366920938463463374607431768211456")))
+	require.Equal(t, u64(7), v)
+}
+
+func TestUint128MarshalTextDefaultBase(t *testing.T) {
+	defer func(base int) { TextMarshalBase = base }(TextMarshalBase)
+
+	out, err := u64(255).MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "255", string(out))
+
+	TextMarshalBase = 16
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randUint128(bts)
+		out, err := u.MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, "0x", string(out[:2]))
+
+		var v Uint128
+		require.NoError(t, v.UnmarshalText(out))
+		require.Equal(t, u, v)
+	}
+}
+
+func TestUint128Pow(t *testing.T) {
+	for idx, tc := range []struct {
+		u   Uint128
+		exp uint
+		out Uint128
+	}{
+		{u64(0), 0, u64(1)},
+		{MaxUint128, 0, u64(1)},
+		{u64(2), 127, u64(1).Lsh(127)},
+		{u64(2), 128, u64(0)}, // Overflow wraps
+		{u64(10), 38, u128s("100000000000000000000000000000000000000")},
+		{MaxUint128, 1, MaxUint128},
+		{MaxUint128, 2, u64(1)}, // (-1)^2 mod 2^128
+		{MaxUint128, 3, MaxUint128},
+	} {
+		t.Run(fmt.Sprintf("%d/%s**%d", idx, tc.u, tc.exp), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.Pow(tc.exp))
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u, exp := randUint128(bts), uint(i%300)
+		expected := new(big.Int).Exp(u.AsBigInt(), new(big.Int).SetUint64(uint64(exp)), wrapBigUint128)
+		require.Equal(t, expected.String(), u.Pow(exp).String(), "%s**%d", u, exp)
+	}
+}
+
+func TestUint128PowMod(t *testing.T) {
+	check := func(u, exp, mod Uint128) {
+		expected := new(big.Int).Exp(u.AsBigInt(), exp.AsBigInt(), mod.AsBigInt())
+		result := u.PowMod(exp, mod)
+		require.Equal(t, expected.String(), result.String(), "%s**%s %% %s", u, exp, mod)
+	}
+
+	for _, tc := range []struct{ u, exp, mod Uint128 }{
+		{u64(0), u64(0), u64(7)},
+		{u64(0), u64(0), u64(1)},
+		{MaxUint128, u64(0), MaxUint128},
+		{MaxUint128, MaxUint128, MaxUint128},
+		{MaxUint128, MaxUint128, MaxUint128.Dec()},
+		{MaxUint128.Dec(), MaxUint128, MaxUint128},
+		{MaxUint128, MaxUint128, u64(maxUint64)},
+		{u64(3), u64(1000), u128s("170141183460469231731687303715884105727")}, // 2^127-1 is prime
+		{u64(2), u64(64), u64(maxUint64)},
+	} {
+		check(tc.u, tc.exp, tc.mod)
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 500; i++ {
+		mod := randUint128(bts)
+		if mod.IsZero() {
+			continue
+		}
+		check(randUint128(bts), randUint128(bts), mod)
+	}
+
+	require.Panics(t, func() { u64(2).PowMod(u64(2), u64(0)) })
+}
+
+func TestUint128QuoRemPow2(t *testing.T) {
+	bts := make([]byte, 16)
+	for i := 0; i < 100; i++ {
+		u := randUint128(bts)
+		for log2 := uint(0); log2 < 128; log2++ {
+			q, r := u.QuoRemPow2(log2)
+			eq, er := u.QuoRem(u64(1).Lsh(log2))
+			require.Equal(t, eq, q, "%s / 2^%d", u, log2)
+			require.Equal(t, er, r, "%s %% 2^%d", u, log2)
+		}
+	}
+
+	q, r := MaxUint128.QuoRemPow2(127)
+	require.Equal(t, u64(1), q)
+	require.Equal(t, maxInt128AsUint128, r)
+
+	require.Panics(t, func() { u64(1).QuoRemPow2(128) })
+}
+
+func TestUint128MulFull(t *testing.T) {
+	bts := make([]byte, 16)
+	check := func(a, b Uint128) {
+		hi, lo := a.MulFull(b)
+		result := new(big.Int).Lsh(hi.AsBigInt(), 128)
+		result.Or(result, lo.AsBigInt())
+		expected := new(big.Int).Mul(a.AsBigInt(), b.AsBigInt())
+		require.True(t, expected.Cmp(result) == 0, "%s * %s: expected %s, found %s", a, b, expected, result)
+		require.Equal(t, a.Mul(b), lo)
+		require.Equal(t, expected.BitLen() > 128, !hi.IsZero())
+	}
+
+	check(MaxUint128, MaxUint128)
+	check(MaxUint128, u64(1))
+	check(u64(0), MaxUint128)
+	for i := 0; i < 10000; i++ {
+		check(randUint128(bts), randUint128(bts))
+	}
+}
+
+func TestUint128ModInverse(t *testing.T) {
+	for idx, tc := range []struct {
+		u, mod, inv Uint128
+		ok          bool
+	}{
+		{u64(3), u64(11), u64(4), true},
+		{u64(10), u64(17), u64(12), true},
+		{u64(2), u64(4), u64(0), false},
+		{u64(0), u64(7), u64(0), false},
+		{u64(1), u64(7), u64(1), true},
+		{u64(6), u64(7), u64(6), true},
+		{u64(5), u64(1), u64(0), true},
+		{u64(2), MaxUint128, u128s("170141183460469231731687303715884105728"), true},
+		{MaxUint128.Dec(), MaxUint128, MaxUint128.Dec(), true}, // (-1)^-1 == -1
+		{u64(3), u64(1).Lsh(127), u128s("56713727820156410577229101238628035243"), true},
+	} {
+		t.Run(fmt.Sprintf("%d/%s^-1%%%s", idx, tc.u, tc.mod), func(t *testing.T) {
+			inv, ok := tc.u.ModInverse(tc.mod)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.inv, inv)
+			if ok && tc.mod != u64(1) {
+				require.Equal(t, u64(1), mulMod(tc.u.Rem(tc.mod), inv, tc.mod))
+			}
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u, mod := randUint128(bts), randUint128(bts)
+		if mod.IsZero() {
+			continue
+		}
+		inv, ok := u.ModInverse(mod)
+		expected := new(big.Int).ModInverse(u.AsBigInt(), mod.AsBigInt())
+		require.Equal(t, expected != nil, ok, "%s^-1 %% %s", u, mod)
+		if ok {
+			require.Equal(t, expected.String(), inv.String(), "%s^-1 %% %s", u, mod)
+		}
+	}
+
+	require.Panics(t, func() { u64(1).ModInverse(u64(0)) })
+}
+
+func TestUint128MulDiv(t *testing.T) {
+	check := func(u, mul, div Uint128) {
+		v := new(big.Int).Mul(u.AsBigInt(), mul.AsBigInt())
+		v.Quo(v, div.AsBigInt())
+		v = simulateBigUint128Overflow(v)
+		require.Equal(t, v.String(), u.MulDiv(mul, div).String(), "%s*%s/%s", u, mul, div)
+	}
+
+	for idx, tc := range []struct {
+		u, mul, div, out Uint128
+	}{
+		{u64(6), u64(7), u64(3), u64(14)},
+		{MaxUint128, MaxUint128, MaxUint128, MaxUint128},
+		{MaxUint128, u64(3), u64(4), u128s("255211775190703847597530955573826158591")},
+		{u64(1).Lsh(100), u64(1).Lsh(100), u64(1).Lsh(90), u64(1).Lsh(110)},
+		{u64(1000000007), u64(3), u64(2), u64(1500000010)},
+		{MaxUint128, MaxUint128, u64(1), u64(1)}, // Quotient overflows and wraps, like Mul
+		{u64(0), MaxUint128, u64(7), u64(0)},
+	} {
+		t.Run(fmt.Sprintf("%d/%s*%s/%s", idx, tc.u, tc.mul, tc.div), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.MulDiv(tc.mul, tc.div))
+			check(tc.u, tc.mul, tc.div)
+		})
+	}
+
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u, mul, div := randUint128(bts), randUint128(bts), randUint128(bts)
+		if div.IsZero() {
+			continue
+		}
+		check(u, mul, div)
+	}
+
+	require.Panics(t, func() { u64(1).MulDiv(u64(1), u64(0)) })
+}
+
 func TestUint128Mul(t *testing.T) {
 
 	u := Uint128From64(maxUint64)
@@ -499,6 +1644,33 @@ func TestUint128MustUint64(t *testing.T) {
 	}
 }
 
+func TestUint128MustInt128(t *testing.T) {
+	for _, tc := range []struct {
+		a  Uint128
+		ok bool
+	}{
+		{u64(0), true},
+		{u64(maxUint64), true},
+		{maxInt128AsUint128, true},
+		{minInt128AsUint128, false},
+		{MaxUint128, false},
+	} {
+		t.Run(fmt.Sprintf("(%s).i128?==%v", tc.a, tc.ok), func(t *testing.T) {
+			i, ok := tc.a.Int128Checked()
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.ok, tc.a.IsInt128())
+			if !tc.ok {
+				require.Equal(t, Int128{}, i)
+				require.Panics(t, func() { tc.a.MustInt128() })
+				return
+			}
+			require.Equal(t, tc.a.AsInt128(), i)
+			require.Equal(t, i, tc.a.MustInt128())
+			require.Equal(t, tc.a, i.AsUint128())
+		})
+	}
+}
+
 func TestUint128Not(t *testing.T) {
 	for idx, tc := range []struct {
 		a, b Uint128
@@ -563,6 +1735,85 @@ func TestUint128QuoRem(t *testing.T) {
 	}
 }
 
+func TestUint128DivisorQuoRem(t *testing.T) {
+	require.Panics(t, func() { NewUint128Divisor(u64(0)) })
+
+	bts := make([]byte, 16)
+	for idx, by := range []Uint128{
+		u64(1),
+		u64(2),
+		u64(3),
+		u64(1 << 63),
+		u64(maxUint64),
+		u128s("0x1 0000000000000000"),
+		u128s("0x123456789012345678901234"),
+		MaxUint128,
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, by), func(t *testing.T) {
+			d := NewUint128Divisor(by)
+			require.Equal(t, by, d.Divisor())
+
+			for _, u := range []Uint128{u64(0), by, by.Dec(), by.Inc(), MaxUint128} {
+				q, r := d.QuoRem(u)
+				eq, er := u.QuoRem(by)
+				require.Equal(t, eq, q, "%s / %s", u, by)
+				require.Equal(t, er, r, "%s %% %s", u, by)
+			}
+			for i := 0; i < 1000; i++ {
+				u := randUint128(bts)
+				q, r := d.QuoRem(u)
+				eq, er := u.QuoRem(by)
+				require.Equal(t, eq, q, "%s / %s", u, by)
+				require.Equal(t, er, r, "%s %% %s", u, by)
+			}
+		})
+	}
+}
+
+func TestRandUint128Range(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(1))
+
+	t.Run("small", func(t *testing.T) {
+		const samples = 40000
+		lo, hi := u64(10), u64(14)
+
+		counts := map[Uint128]int{}
+		for i := 0; i < samples; i++ {
+			v := RandUint128Range(rng, lo, hi)
+			require.True(t, v.GreaterOrEqualTo(lo) && v.LessThan(hi), "%s out of range", v)
+			counts[v]++
+		}
+
+		// Each value expects 10000 hits; a 10% window is many standard
+		// deviations wide, so this only fails if the sampling is biased:
+		require.Len(t, counts, 4)
+		for v, c := range counts {
+			require.True(t, c > 9000 && c < 11000, "value %s has %d hits", v, c)
+		}
+	})
+
+	t.Run("wide", func(t *testing.T) {
+		lo, hi := u64(maxUint64-1), u128s("18446744073709551618")
+		for i := 0; i < 1000; i++ {
+			v := RandUint128Range(rng, lo, hi)
+			require.True(t, v.GreaterOrEqualTo(lo) && v.LessThan(hi), "%s out of range", v)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		require.Equal(t, u64(5), RandUint128Range(rng, u64(5), u64(6)))
+	})
+
+	t.Run("full", func(t *testing.T) {
+		require.NotPanics(t, func() { RandUint128Range(rng, u64(0), u64(0)) })
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Panics(t, func() { RandUint128Range(rng, u64(2), u64(2)) })
+		require.Panics(t, func() { RandUint128Range(rng, u64(3), u64(2)) })
+	})
+}
+
 func TestUint128ReverseBytes(t *testing.T) {
 	for _, tc := range []struct {
 		u Uint128
@@ -639,6 +1890,35 @@ func TestUint128RotateLeft(t *testing.T) {
 	}
 }
 
+func TestUint128Sqrt(t *testing.T) {
+	check := func(u Uint128) {
+		r := u.Sqrt()
+		expected := new(big.Int).Sqrt(u.AsBigInt())
+		require.True(t, expected.Cmp(r.AsBigInt()) == 0, "sqrt(%s): expected %s, found %s", u, expected, r)
+
+		// r*r <= u < (r+1)*(r+1), where (r+1)^2 may be 2^128:
+		require.True(t, r.Mul(r).LessOrEqualTo(u))
+		r1 := new(big.Int).Add(r.AsBigInt(), big1)
+		require.True(t, r1.Mul(r1, r1).Cmp(u.AsBigInt()) > 0)
+	}
+
+	square := u64(maxUint64).Mul(u64(maxUint64)) // (2^64-1)^2
+	for _, u := range []Uint128{
+		u64(0), u64(1), u64(2), u64(3), u64(4), u64(15), u64(16), u64(17),
+		u64(maxUint64), u64(maxUint64).Inc(),
+		square, square.Dec(), square.Inc(),
+		MaxUint128, MaxUint128.Dec(), maxInt128AsUint128,
+	} {
+		check(u)
+	}
+	require.Equal(t, u64(maxUint64), MaxUint128.Sqrt())
+
+	bts := make([]byte, 16)
+	for i := 0; i < 10000; i++ {
+		check(randUint128(bts))
+	}
+}
+
 func TestUint128Rsh(t *testing.T) {
 	for _, tc := range []struct {
 		u  Uint128
@@ -669,6 +1949,43 @@ func TestUint128Rsh(t *testing.T) {
 	}
 }
 
+func TestUint128ScientificString(t *testing.T) {
+	for idx, tc := range []struct {
+		u   Uint128
+		sig int
+		out string
+	}{
+		{u64(0), 3, "0.00e+00"},
+		{u64(7), 1, "7e+00"},
+		{u64(7), 3, "7.00e+00"},
+		{u64(12345), 3, "1.23e+04"},
+		{u64(12355), 3, "1.24e+04"},
+		{u64(999), 2, "1.0e+03"},
+		{u64(999), 0, "1e+03"},
+		{u64(maxUint64), 5, "1.8447e+19"},
+		{MaxUint128, 3, "3.40e+38"},
+		{MaxUint128, 39, "3.40282366920938463463374607431768211455e+38"},
+		{MaxUint128, 42, "3.40282366920938463463374607431768211455000e+38"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.u), func(t *testing.T) {
+			require.Equal(t, tc.out, tc.u.ScientificString(tc.sig))
+		})
+	}
+}
+
+func TestUint128ScientificStringExponent(t *testing.T) {
+	bts := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randUint128(bts)
+		digits := len(u.String())
+
+		// With every digit kept there's no rounding, so the exponent must be
+		// exactly the digit count minus one:
+		s := u.ScientificString(digits)
+		require.Equal(t, fmt.Sprintf("e+%02d", digits-1), s[strings.IndexByte(s, 'e'):], "%s", u)
+	}
+}
+
 func TestUint128Scan(t *testing.T) {
 	for idx, tc := range []struct {
 		in  string
@@ -676,7 +1993,16 @@ func TestUint128Scan(t *testing.T) {
 		ok  bool
 	}{
 		{"1", u64(1), true},
-		{"0xFF", zeroUint128, false},
+		{"0xFF", u64(255), true},
+		{"0Xff", u64(255), true},
+		{"0o17", u64(15), true},
+		{"017", u64(15), true}, // leading-zero octal
+		{"0b101", u64(5), true},
+		{"0", u64(0), true},
+		{"0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", MaxUint128, true},
+		{"0x100000000000000000000000000000000", zeroUint128, false},
+		{"0xZZ", zeroUint128, false},
+		{"09", zeroUint128, false},
 		{"-1", zeroUint128, false},
 		{"340282366920938463463374607431768211456", zeroUint128, false},
 	} {
@@ -834,6 +2160,12 @@ var benchUint128CmpCases = []struct {
 	{u128s("0xEFFFFFFFFFFFFFFF"), u128s("0xFFFFFFFFFFFFFFFF"), "lesslo"},
 	{u128s("0xFFFFFFFFFFFFFFFF FFFFFFFFFFFFFFFF"), u128s("0xEFFFFFFFFFFFFFFF FFFFFFFFFFFFFFFF"), "greaterhi"},
 	{u128s("0xFFFFFFFFFFFFFFFF"), u128s("0xEFFFFFFFFFFFFFFF"), "greaterlo"},
+
+	// Both operands fit in 64 bits, which is by far the most common case for
+	// counters and indices:
+	{u64(12345), u64(12345), "both64/equal"},
+	{u64(12345), u64(67890), "both64/less"},
+	{u64(67890), u64(12345), "both64/greater"},
 }
 
 func BenchmarkUint128Cmp(b *testing.B) {
@@ -1021,6 +2353,34 @@ func BenchmarkUint128QuoRem(b *testing.B) {
 	}
 }
 
+func BenchmarkUint128DivisorQuoRem(b *testing.B) {
+	for idx, bc := range benchQuoCases {
+		b.Run(fmt.Sprintf("%d/%s", idx, bc.name), func(b *testing.B) {
+			d := NewUint128Divisor(bc.divisor)
+			for i := 0; i < b.N; i++ {
+				benchUint128Result, _ = d.QuoRem(bc.dividend)
+			}
+		})
+	}
+}
+
+func BenchmarkUint128QuoRemPow2(b *testing.B) {
+	u := u128s("0x98765432109876543210987654321098")
+	for _, log2 := range []uint{1, 63, 64, 100} {
+		by := u64(1).Lsh(log2)
+		b.Run(fmt.Sprintf("quorem/%d", log2), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				benchUint128Result, _ = u.QuoRem(by)
+			}
+		})
+		b.Run(fmt.Sprintf("pow2/%d", log2), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				benchUint128Result, _ = u.QuoRemPow2(log2)
+			}
+		})
+	}
+}
+
 func BenchmarkUint128QuoRemTZ(b *testing.B) {
 	type tc struct {
 		zeros  int
@@ -1208,3 +2568,88 @@ var trimFloatPattern = regexp.MustCompile(`(\.0+$|(\.\d+[1-9])\0+$)`)
 func cleanFloatStr(str string) string {
 	return trimFloatPattern.ReplaceAllString(str, "$2")
 }
+
+// uint128SmallValues are the operands for the exhaustive bitwise tests. Fuzzing
+// with random 128-bit values rarely produces these, but they're where mistakes
+// in carrying bits between the limbs show up.
+var uint128SmallValues = []Uint128{
+	u64(0), u64(1), u64(2), u64(maxUint64),
+	u64(1).Lsh(64), MaxUint128,
+}
+
+// bigUint128Wrap reduces b, which may be negative, to the Uint128 two's
+// complement range [0, 2^128).
+func bigUint128Wrap(b *big.Int) *big.Int {
+	return new(big.Int).And(b, maxBigUint128)
+}
+
+func TestUint128BitwiseExhaustive(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		u    func(a, b Uint128) Uint128
+		big  func(z, a, b *big.Int) *big.Int
+	}{
+		{"and", Uint128.And, (*big.Int).And},
+		{"or", Uint128.Or, (*big.Int).Or},
+		{"xor", Uint128.Xor, (*big.Int).Xor},
+		{"andnot", Uint128.AndNot, (*big.Int).AndNot},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, a := range uint128SmallValues {
+				for _, b := range uint128SmallValues {
+					expected := bigUint128Wrap(tc.big(new(big.Int), a.AsBigInt(), b.AsBigInt()))
+					result := tc.u(a, b)
+					require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+						"%#x %s %#x: expected %#x, found %#x", a.AsBigInt(), tc.name, b.AsBigInt(), expected, result.AsBigInt())
+				}
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		u    func(a Uint128, b Uint64) Uint128
+		big  func(z, a, b *big.Int) *big.Int
+	}{
+		{"and64", Uint128.And64, (*big.Int).And},
+		{"or64", Uint128.Or64, (*big.Int).Or},
+		{"xor64", Uint128.Xor64, (*big.Int).Xor},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, a := range uint128SmallValues {
+				for _, b := range []Uint64{0, 1, 2, maxUint64} {
+					expected := bigUint128Wrap(tc.big(new(big.Int), a.AsBigInt(), new(big.Int).SetUint64(uint64(b))))
+					result := tc.u(a, b)
+					require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+						"%#x %s %#x: expected %#x, found %#x", a.AsBigInt(), tc.name, b, expected, result.AsBigInt())
+				}
+			}
+		})
+	}
+
+	t.Run("not", func(t *testing.T) {
+		for _, a := range uint128SmallValues {
+			// big.Int's Not is -a-1, which wraps to 2^128-a-1:
+			expected := bigUint128Wrap(new(big.Int).Not(a.AsBigInt()))
+			result := a.Not()
+			require.True(t, expected.Cmp(result.AsBigInt()) == 0,
+				"^%#x: expected %#x, found %#x", a.AsBigInt(), expected, result.AsBigInt())
+			require.Equal(t, a, result.Not())
+		}
+	})
+
+	t.Run("identities", func(t *testing.T) {
+		for _, a := range uint128SmallValues {
+			for _, b := range uint128SmallValues {
+				require.Equal(t, a, a.Xor(b).Xor(b))
+				require.Equal(t, a.And(b.Not()), a.AndNot(b))
+				require.Equal(t, a.And(b).Not(), a.Not().Or(b.Not()))
+				require.Equal(t, a.Or(b).Not(), a.Not().And(b.Not()))
+				require.Equal(t, a.Or(b), a.Xor(b).Or(a.And(b)))
+				require.Equal(t, a.And(b), b.And(a))
+				require.Equal(t, a.Or(b), b.Or(a))
+				require.Equal(t, a.Xor(b), b.Xor(a))
+			}
+		}
+	})
+}
@@ -12,10 +12,14 @@ func isInside() {
 	panic("implement me")
 }
 
+// IsPointInsidePlanes reports whether point is inside every plane, to within
+// margin. Each plane is stored as its normal in X, Y and Z and its offset in
+// W, so that a point p is on the plane when normal.p + W == 0, and inside it
+// when that is negative.
 func IsPointInsidePlanes(planes []*Vector3, point *Vector3, margin Scalar) bool {
 	for i := 0; i < len(planes); i++ {
 		n1 := planes[i]
-		dist := (n1.Dot(point) + Scalar(n1.Z)) - margin
+		dist := (n1.Dot(point) + Scalar(n1.W)) - margin
 		if dist > Scalar(0.) {
 			return false
 		}
@@ -23,10 +27,12 @@ func IsPointInsidePlanes(planes []*Vector3, point *Vector3, margin Scalar) bool
 	return true
 }
 
+// AreVerticesBehindPlane reports whether every vertex is behind plane, to
+// within margin, with the plane stored as for IsPointInsidePlanes.
 func AreVerticesBehindPlane(plane *Vector3, vertices []*Vector3, margin Scalar) bool {
 	for i := 0; i < len(vertices); i++ {
 		n1 := vertices[i]
-		dist := (plane.Dot(n1) + Scalar(plane.Z)) - margin
+		dist := (plane.Dot(n1) + Scalar(plane.W)) - margin
 		if dist > Scalar(0.) {
 			return false
 		}
@@ -0,0 +1,41 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPointInsidePlanes(t *testing.T) {
+	// The slab 0 <= z <= 1, where the normals' Z differs from the offsets:
+	planes := []*Vector3{
+		{Z: 1, W: -1},
+		{Z: -1, W: 0},
+	}
+	for _, tc := range []struct {
+		point  Vector3
+		margin Scalar
+		inside bool
+	}{
+		{Vector3{Z: 0.5}, 0, true},
+		{Vector3{X: 100, Y: -100, Z: 0.5}, 0, true},
+		{Vector3{Z: 1}, 0, true},
+		{Vector3{Z: 0}, 0, true},
+		{Vector3{Z: 1.5}, 0, false},
+		{Vector3{Z: -0.5}, 0, false},
+		{Vector3{Z: 1.05}, 0.1, true},
+		{Vector3{Z: -0.05}, 0.1, true},
+		{Vector3{Z: 0.5}, -0.6, false},
+	} {
+		require.Equal(t, tc.inside, IsPointInsidePlanes(planes, &tc.point, tc.margin), "%+v, margin %v", tc.point, tc.margin)
+	}
+}
+
+func TestAreVerticesBehindPlane(t *testing.T) {
+	// z <= 2:
+	plane := &Vector3{Z: 1, W: -2}
+	require.True(t, AreVerticesBehindPlane(plane, []*Vector3{{Z: 1}, {Z: 2}, {X: 5, Z: -3}}, 0))
+	require.False(t, AreVerticesBehindPlane(plane, []*Vector3{{Z: 1}, {Z: 2.5}}, 0))
+	require.True(t, AreVerticesBehindPlane(plane, []*Vector3{{Z: 1}, {Z: 2.5}}, 0.5))
+	require.True(t, AreVerticesBehindPlane(plane, nil, 0))
+}
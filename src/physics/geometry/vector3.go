@@ -1,5 +1,7 @@
 package geometry
 
+import "math"
+
 type Vector3 struct {
 	X float64
 	Y float64
@@ -12,3 +14,73 @@ func (v3 Vector3) Dot(v *Vector3) Scalar {
 		v3.Y*v.Y +
 		v3.Z*v.Z)
 }
+
+// Cross returns the cross product v3 x v of the X, Y and Z components. W is
+// ignored, and is zero in the result.
+func (v3 Vector3) Cross(v *Vector3) Vector3 {
+	return Vector3{
+		X: v3.Y*v.Z - v3.Z*v.Y,
+		Y: v3.Z*v.X - v3.X*v.Z,
+		Z: v3.X*v.Y - v3.Y*v.X,
+	}
+}
+
+// Add returns v3 + v. Only X, Y and Z are summed; W is taken from v3, so
+// adding a direction to a point gives a point.
+func (v3 Vector3) Add(v *Vector3) Vector3 {
+	return Vector3{X: v3.X + v.X, Y: v3.Y + v.Y, Z: v3.Z + v.Z, W: v3.W}
+}
+
+// Subtract returns v3 - v. Only X, Y and Z are subtracted; W is taken from v3.
+func (v3 Vector3) Subtract(v *Vector3) Vector3 {
+	return Vector3{X: v3.X - v.X, Y: v3.Y - v.Y, Z: v3.Z - v.Z, W: v3.W}
+}
+
+// Scale returns v3 with X, Y and Z multiplied by s. W is left alone, so a
+// point with W=1 stays a point.
+func (v3 Vector3) Scale(s Scalar) Vector3 {
+	return Vector3{X: v3.X * float64(s), Y: v3.Y * float64(s), Z: v3.Z * float64(s), W: v3.W}
+}
+
+// Negate returns -v3. As with Scale, W is left alone.
+func (v3 Vector3) Negate() Vector3 {
+	return Vector3{X: -v3.X, Y: -v3.Y, Z: -v3.Z, W: v3.W}
+}
+
+// LengthSquared returns X² + Y² + Z², ignoring W.
+func (v3 Vector3) LengthSquared() Scalar {
+	return v3.Dot(&v3)
+}
+
+// Length returns the Euclidean length of v3, ignoring W.
+func (v3 Vector3) Length() Scalar {
+	return Scalar(math.Sqrt(float64(v3.LengthSquared())))
+}
+
+// Normalize returns v3 scaled to unit length, keeping W. The zero vector has
+// no direction, so it is returned unchanged with ok set to false.
+func (v3 Vector3) Normalize() (out Vector3, ok bool) {
+	l := v3.Length()
+	if l == 0 {
+		return v3, false
+	}
+	return v3.Scale(1 / l), true
+}
+
+// DistanceTo returns the Euclidean distance between v3 and v, ignoring W.
+func (v3 Vector3) DistanceTo(v Vector3) Scalar {
+	return v3.Subtract(&v).Length()
+}
+
+// ApproxEquals reports whether each of the X, Y and Z components of v3 and v
+// differ by no more than eps. W is ignored.
+func (v3 Vector3) ApproxEquals(v Vector3, eps Scalar) bool {
+	return Scalar(math.Abs(v3.X-v.X)) <= eps &&
+		Scalar(math.Abs(v3.Y-v.Y)) <= eps &&
+		Scalar(math.Abs(v3.Z-v.Z)) <= eps
+}
+
+// Equals is ApproxEquals with a tolerance of Epsilon.
+func (v3 Vector3) Equals(v Vector3) bool {
+	return v3.ApproxEquals(v, Epsilon)
+}
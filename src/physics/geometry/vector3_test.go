@@ -0,0 +1,107 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVector3Cross(t *testing.T) {
+	x := Vector3{X: 1}
+	y := Vector3{Y: 1}
+	z := Vector3{Z: 1}
+
+	require.Equal(t, z, x.Cross(&y))
+	require.Equal(t, x, y.Cross(&z))
+	require.Equal(t, y, z.Cross(&x))
+	require.Equal(t, Vector3{Z: -1}, y.Cross(&x))
+	require.Equal(t, Vector3{}, x.Cross(&x))
+
+	// W doesn't participate:
+	require.Equal(t, z, Vector3{X: 1, W: 1}.Cross(&Vector3{Y: 1, W: 1}))
+
+	a := Vector3{X: 1, Y: 2, Z: 3}
+	b := Vector3{X: 4, Y: 5, Z: 6}
+	c := a.Cross(&b)
+	require.Equal(t, Vector3{X: -3, Y: 6, Z: -3}, c)
+	require.Equal(t, Scalar(0), c.Dot(&a))
+	require.Equal(t, Scalar(0), c.Dot(&b))
+}
+
+func TestVector3Algebra(t *testing.T) {
+	a := Vector3{X: 1, Y: 2, Z: 3, W: 1}
+	b := Vector3{X: 4, Y: -5, Z: 0.5, W: 7}
+
+	require.Equal(t, Vector3{X: 5, Y: -3, Z: 3.5, W: 1}, a.Add(&b))
+	require.Equal(t, Vector3{X: -3, Y: 7, Z: 2.5, W: 1}, a.Subtract(&b))
+	require.Equal(t, Vector3{X: 3, Y: -7, Z: -2.5, W: 7}, b.Subtract(&a))
+	require.Equal(t, Vector3{X: 2, Y: 4, Z: 6, W: 1}, a.Scale(2))
+	require.Equal(t, Vector3{W: 1}, a.Scale(0))
+	require.Equal(t, Vector3{X: -1, Y: -2, Z: -3, W: 1}, a.Negate())
+	require.Equal(t, a.Scale(-1), a.Negate())
+
+	sum := a.Add(&b)
+	require.Equal(t, a, sum.Subtract(&b))
+}
+
+func TestVector3Length(t *testing.T) {
+	v := Vector3{X: 3, Y: 4, Z: 12, W: 1}
+	require.Equal(t, Scalar(169), v.LengthSquared())
+	require.Equal(t, Scalar(13), v.Length())
+	require.Equal(t, Scalar(0), Vector3{W: 1}.Length())
+
+	for _, v := range []Vector3{
+		{X: 3, Y: 4, Z: 12, W: 1},
+		{X: -1e-3, Y: 2e-4},
+		{X: 1e150, Y: 1e150, Z: 1e150},
+		{Z: -7},
+	} {
+		n, ok := v.Normalize()
+		require.True(t, ok)
+		require.InDelta(t, 1, float64(n.Length()), Epsilon, "%+v", v)
+		require.Equal(t, v.W, n.W)
+
+		// Same direction:
+		require.InDelta(t, float64(v.Length()), float64(n.Dot(&v)), float64(v.Length())*Epsilon, "%+v", v)
+	}
+
+	n, ok := Vector3{W: 1}.Normalize()
+	require.False(t, ok)
+	require.Equal(t, Vector3{W: 1}, n)
+}
+
+func TestVector3DistanceTo(t *testing.T) {
+	a := Vector3{X: 1, Y: 2, Z: 3, W: 1}
+	b := Vector3{X: 4, Y: 6, Z: 15}
+	require.Equal(t, Scalar(13), a.DistanceTo(b))
+	require.Equal(t, Scalar(13), b.DistanceTo(a))
+	require.Equal(t, Scalar(0), a.DistanceTo(a))
+}
+
+func TestVector3Equals(t *testing.T) {
+	zero := Vector3{}
+	for idx, tc := range []struct {
+		v      Vector3
+		equals bool
+	}{
+		{Vector3{}, true},
+		{Vector3{W: 5}, true}, // W is ignored
+		{Vector3{X: Epsilon}, true},
+		{Vector3{Y: -Epsilon}, true},
+		{Vector3{X: Epsilon, Y: -Epsilon, Z: Epsilon}, true},
+		{Vector3{Z: Epsilon / 2}, true},
+		{Vector3{X: Epsilon * 1.001}, false},
+		{Vector3{Y: -Epsilon * 1.001}, false},
+		{Vector3{Z: 2 * Epsilon}, false},
+		{Vector3{X: 1}, false},
+	} {
+		require.Equal(t, tc.equals, zero.Equals(tc.v), "%d: %+v", idx, tc.v)
+		require.Equal(t, tc.equals, tc.v.Equals(zero), "%d: %+v", idx, tc.v)
+	}
+
+	a := Vector3{X: 1, Y: 2, Z: 3}
+	require.True(t, a.ApproxEquals(Vector3{X: 1.05, Y: 1.95, Z: 3}, 0.1))
+	require.False(t, a.ApproxEquals(Vector3{X: 1.05, Y: 1.95, Z: 3.2}, 0.1))
+	require.False(t, a.ApproxEquals(Vector3{X: 1.05}, 0))
+	require.True(t, a.ApproxEquals(a, 0))
+}
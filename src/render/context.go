@@ -17,4 +17,26 @@ type Context interface {
 	PresentImage(imageIndex int) (outdated bool, err error)
 }
 
-type context struct {}
\ No newline at end of file
+type context struct {
+	platform Platform
+
+	onPrepare    func() error
+	onCleanup    func() error
+	onInvalidate func(imageIndex int) error
+}
+
+func (c *context) SetOnPrepare(onPrepare func() error) {
+	c.onPrepare = onPrepare
+}
+
+func (c *context) SetOnCleanup(onCleanup func() error) {
+	c.onCleanup = onCleanup
+}
+
+func (c *context) SetOnInvalidate(onInvalidate func(imageIndex int) error) {
+	c.onInvalidate = onInvalidate
+}
+
+func (c *context) Platform() Platform {
+	return c.platform
+}
@@ -1,3 +1,13 @@
 package render
 
 type Platform interface {}
+
+// NoopPlatform is a Platform with no window system or GPU behind it. It's meant
+// for tests and headless tools that only need a Context's wiring, such as its
+// lifecycle callbacks and error handling, without creating a Vulkan device.
+//
+// Platform doesn't declare any methods yet; as it grows, NoopPlatform gains
+// implementations that return benign defaults rather than errors.
+type NoopPlatform struct{}
+
+var _ Platform = NoopPlatform{}